@@ -2,28 +2,66 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/stack-radar/stackradar/pkg/detector"
 )
 
+var (
+	checkPath   string
+	checkPolicy string
+)
+
 var checkCmd = &cobra.Command{
 	Use:   "check",
-	Short: "Check dependencies",
-	Long:  `Checks if required dependencies like GitHub Linguist are available.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Checking dependencies...")
-		fmt.Println()
+	Short: "Check dependencies or enforce a policy",
+	Long: `Checks if required dependencies like GitHub Linguist are available.
 
+With --policy, detects the tech stack of a repository and checks it against a
+policy of allowed languages, runtime versions, build tools and image registries.
+Violations are printed with the files that show them, and the command fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		d := detector.NewDetector()
-		if d.LinguistAvailable() {
-			fmt.Println("✓ GitHub Linguist: Available")
-		} else {
-			fmt.Println("⚠ GitHub Linguist: Not available")
+		if checkPolicy == "" {
+			fmt.Println("Checking dependencies...")
+			fmt.Println()
+
+			if d.LinguistAvailable() {
+				fmt.Println("✓ GitHub Linguist: Available")
+			} else {
+				fmt.Println("⚠ GitHub Linguist: Not available")
+			}
+			return nil
+		}
+
+		policy, err := detector.LoadPolicy(checkPolicy)
+		if err != nil {
+			return err
 		}
+		violations, err := d.Check(checkPath, policy)
+		if err != nil {
+			return fmt.Errorf("detection failed: %w", err)
+		}
+		if len(violations) == 0 {
+			fmt.Println("✅ No policy violations")
+			return nil
+		}
+
+		for _, v := range violations {
+			fmt.Printf("❌ %s: %s\n", v.Rule, v.Message)
+			if len(v.Files) > 0 {
+				fmt.Printf("   %s\n", strings.Join(v.Files, ", "))
+			}
+		}
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d policy violation(s)", len(violations))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().StringVarP(&checkPath, "path", "p", ".", "Local repository path")
+	checkCmd.Flags().StringVar(&checkPolicy, "policy", "", "Policy YAML to enforce")
 }
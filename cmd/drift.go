@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/stack-radar/stackradar/pkg/detector"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	driftPath      string
+	driftFormat    string
+	driftRecursive bool
+)
+
+var driftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Report runtime versions that disagree across files",
+	Long: `Reads the runtime versions pinned by version files (.nvmrc, .python-version,
+.tool-versions, ...), Dockerfile and Compose images, GitHub Actions setup steps
+and GitLab CI images, and reports runtimes whose versions disagree with each
+other or with the detected version. A less precise version agrees with the
+versions it is a prefix of, so node 20 agrees with 20.11.1. When the manifest
+declares a range such as ">=18" or "^3.11", the pins must satisfy the range.
+
+The command fails when it finds drift. Use --recursive to check every project
+in a monorepo; CI configuration is read at the repository root and matched to
+projects by the job's working directory.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		drifts, err := detector.NewDetector().Drift(driftPath, driftRecursive)
+		if err != nil {
+			return fmt.Errorf("detection failed: %w", err)
+		}
+
+		switch driftFormat {
+		case "json":
+			data, err := json.MarshalIndent(drifts, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		case "yaml":
+			data, err := yaml.Marshal(drifts)
+			if err != nil {
+				return fmt.Errorf("failed to marshal YAML: %w", err)
+			}
+			fmt.Print(string(data))
+		default: // table
+			if len(drifts) == 0 {
+				fmt.Println("✅ All pinned runtime versions agree")
+				return nil
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "PROJECT\tRUNTIME\tVERSION\tFILE\tSOURCE")
+			for _, d := range drifts {
+				if d.Detected != "" {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", d.Project, d.Runtime, d.Detected, "-", "detected")
+				}
+				for _, pin := range d.Pins {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", d.Project, d.Runtime, pin.Version, pin.File, orDash(pin.Source))
+				}
+			}
+			w.Flush()
+		}
+
+		if len(drifts) > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d runtime(s) with drifting versions", len(drifts))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(driftCmd)
+
+	driftCmd.Flags().StringVarP(&driftPath, "path", "p", ".", "Local repository path")
+	driftCmd.Flags().StringVarP(&driftFormat, "format", "f", "table", "Output format (table, yaml, json)")
+	driftCmd.Flags().BoolVarP(&driftRecursive, "recursive", "r", false, "Check every project in a monorepo")
+}
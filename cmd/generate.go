@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/stack-radar/stackradar/pkg/catalog"
+	"github.com/stack-radar/stackradar/pkg/detector"
+	"github.com/stack-radar/stackradar/pkg/generator"
+	"github.com/stack-radar/stackradar/pkg/lockfile"
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+var (
+	generatePath      string
+	generateOutput    string
+	generateProvider  string
+	generateRecursive bool
+	generateCatalog   string
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate build files from the detected tech stack",
+}
+
+var generateDockerfileCmd = &cobra.Command{
+	Use:   "dockerfile",
+	Short: "Generate a multi-stage Dockerfile",
+	Long: `Generates a multi-stage Dockerfile from the detected tech stack. The build stage
+uses ci_image_tag with the detected install and build commands, copying lockfiles
+first and mounting package manager caches; the final stage runs the application
+in runtime_image_tag.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d, _, err := generateDetector()
+		if err != nil {
+			return err
+		}
+		result, err := detectForGenerate(d, generatePath)
+		if err != nil {
+			return err
+		}
+		dockerfile, err := generator.Dockerfile(generatePath, result)
+		if err != nil {
+			return err
+		}
+		return writeGenerated(generateOutput, dockerfile)
+	},
+}
+
+var generateCICmd = &cobra.Command{
+	Use:   "ci",
+	Short: "Generate a GitHub Actions workflow or GitLab CI pipeline",
+	Long: `Generates a CI pipeline that installs the detected runtime version, caches
+dependencies keyed by lockfiles, and runs the install, build and test commands.
+
+GitHub Actions jobs use the setup action of the runtime; GitLab CI jobs run in
+ci_image_tag. With --recursive, every project of a monorepo is built: GitHub
+Actions as a job matrix per language, GitLab CI as a job per project.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if generateProvider != "github" && generateProvider != "gitlab" {
+			return fmt.Errorf("unknown provider %q: use github or gitlab", generateProvider)
+		}
+
+		d, _, err := generateDetector()
+		if err != nil {
+			return err
+		}
+		paths := []string{"."}
+		if generateRecursive {
+			if paths, err = d.FindProjects(generatePath); err != nil {
+				return fmt.Errorf("failed to find projects: %w", err)
+			}
+		}
+		var projects []generator.Project
+		for _, project := range paths {
+			result, err := detectForGenerate(d, filepath.Join(generatePath, project))
+			if err != nil {
+				if generateRecursive {
+					fmt.Fprintf(os.Stderr, "⚠️  %s: %s\n", project, err)
+					continue
+				}
+				return err
+			}
+			projects = append(projects, generator.Project{Path: project, Stack: result})
+		}
+
+		var pipeline string
+		if generateProvider == "github" {
+			pipeline, err = generator.GitHubWorkflow(projects)
+		} else {
+			pipeline, err = generator.GitLabCI(generatePath, projects)
+		}
+		if err != nil {
+			return err
+		}
+		return writeGenerated(generateOutput, pipeline)
+	},
+}
+
+var generateDevcontainerCmd = &cobra.Command{
+	Use:   "devcontainer",
+	Short: "Generate a devcontainer.json for dev containers and Codespaces",
+	Long: `Generates .devcontainer/devcontainer.json from the detected tech stack. The
+container provides the runtime version of ci_image_tag through the matching
+mcr.microsoft.com/devcontainers image, or a feature when no image publishes that
+version, adds features for build tools such as Poetry, pnpm or Gradle, and runs
+the install command once created. Runtimes without either run in ci_image_tag.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d, c, err := generateDetector()
+		if err != nil {
+			return err
+		}
+		result, err := detectForGenerate(d, generatePath)
+		if err != nil {
+			return err
+		}
+		config, err := generator.Devcontainer(generatePath, result, c)
+		if err != nil {
+			return err
+		}
+		output := generateOutput
+		switch output {
+		case "":
+			output = filepath.Join(generatePath, ".devcontainer", "devcontainer.json")
+		case "-":
+			output = ""
+		}
+		return writeGenerated(output, config)
+	},
+}
+
+// generateDetector returns a detector resolving image tags in the catalog given
+// with --catalog, or in the embedded one, together with that catalog
+func generateDetector() (*detector.Detector, *catalog.Catalog, error) {
+	c := catalog.Default()
+	if generateCatalog != "" {
+		var err error
+		if c, err = catalog.Load(generateCatalog); err != nil {
+			return nil, nil, err
+		}
+	}
+	d := detector.NewDetector()
+	d.SetCatalog(c)
+	return d, c, nil
+}
+
+// detectForGenerate detects the tech stack of a repository, pins image tags when it
+// has a lock file and reports detection warnings on stderr
+func detectForGenerate(d *detector.Detector, repoPath string) (*models.TechStack, error) {
+	result, err := d.Detect(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("detection failed: %w", err)
+	}
+	if lock, err := lockfile.Load(filepath.Join(repoPath, lockfile.FileName)); err == nil {
+		lock.Apply(result)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+	return result, nil
+}
+
+// writeGenerated writes generated content to a file, or to stdout when no file is given
+func writeGenerated(file, content string) error {
+	if file == "" {
+		fmt.Print(content)
+		return nil
+	}
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✅ Output written to: %s\n", file)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+	generateCmd.AddCommand(generateDockerfileCmd)
+	generateCmd.AddCommand(generateCICmd)
+	generateCmd.AddCommand(generateDevcontainerCmd)
+
+	generateCmd.PersistentFlags().StringVarP(&generatePath, "path", "p", ".", "Local repository path")
+	generateCmd.PersistentFlags().StringVar(&generateCatalog, "catalog", "", "Image tag catalog JSON replacing the embedded one")
+	generateDockerfileCmd.Flags().StringVarP(&generateOutput, "output", "o", "", "Output file, e.g. Dockerfile (default stdout)")
+	generateCICmd.Flags().StringVarP(&generateOutput, "output", "o", "", "Output file, e.g. .github/workflows/ci.yml (default stdout)")
+	generateDevcontainerCmd.Flags().StringVarP(&generateOutput, "output", "o", "", "Output file, - for stdout (default <path>/.devcontainer/devcontainer.json)")
+	generateCICmd.Flags().StringVar(&generateProvider, "provider", "github", "CI provider (github, gitlab)")
+	generateCICmd.Flags().BoolVarP(&generateRecursive, "recursive", "r", false, "Build every project of a monorepo")
+}
@@ -2,20 +2,29 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"github.com/stack-radar/stackradar/pkg/catalog"
 	"github.com/stack-radar/stackradar/pkg/detector"
+	"github.com/stack-radar/stackradar/pkg/lifecycle"
+	"github.com/stack-radar/stackradar/pkg/lockfile"
 	"gopkg.in/yaml.v3"
 )
 
 var (
-	path      string
-	directory string
-	format    string
-	output    string
-	quiet     bool
+	path          string
+	directory     string
+	format        string
+	output        string
+	quiet         bool
+	catalogFile   string
+	lockPin       string
+	lifecycleFile string
+	failOnEOL     bool
 )
 
 var getCmd = &cobra.Command{
@@ -36,11 +45,42 @@ var getCmd = &cobra.Command{
 
 		// Detect tech stack
 		d := detector.NewDetector()
+		if catalogFile != "" {
+			c, err := catalog.Load(catalogFile)
+			if err != nil {
+				return err
+			}
+			d.SetCatalog(c)
+		}
+		if lifecycleFile != "" {
+			l, err := lifecycle.Load(lifecycleFile)
+			if err != nil {
+				return err
+			}
+			d.SetLifecycle(l)
+		}
 		result, err := d.Detect(repoPath)
 		if err != nil {
 			return fmt.Errorf("detection failed: %w", err)
 		}
 
+		// Pin image tags to the digests recorded in the lock file, when there is one
+		lockPinFile := lockPin
+		if lockPinFile == "" {
+			lockPinFile = filepath.Join(repoPath, lockfile.FileName)
+		}
+		if lock, err := lockfile.Load(lockPinFile); err == nil {
+			lock.Apply(result)
+		} else if lockPin != "" || !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		if !quiet {
+			for _, warning := range result.Warnings {
+				fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+			}
+		}
+
 		// Format output
 		var outputText string
 		switch format {
@@ -72,6 +112,10 @@ var getCmd = &cobra.Command{
 			fmt.Print(outputText)
 		}
 
+		if runtime := result.Language.Runtime; failOnEOL && runtime.EOL != nil && *runtime.EOL {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%s %s reached end of life on %s", runtime.Name, runtime.Version, runtime.EOLDate)
+		}
 		return nil
 	},
 }
@@ -84,4 +128,8 @@ func init() {
 	getCmd.Flags().StringVarP(&format, "format", "f", "yaml", "Output format (yaml, json, env)")
 	getCmd.Flags().StringVarP(&output, "output", "o", "", "Output file")
 	getCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress messages")
+	getCmd.Flags().StringVar(&catalogFile, "catalog", "", "Image tag catalog JSON replacing the embedded one")
+	getCmd.Flags().StringVar(&lifecycleFile, "lifecycle", "", "Runtime lifecycle JSON replacing the embedded one")
+	getCmd.Flags().BoolVar(&failOnEOL, "fail-on-eol", false, "Exit with an error when the runtime reached end of life")
+	getCmd.Flags().StringVar(&lockPin, "lock", "", "Lock file with image digests (default <path>/stackradar.lock)")
 }
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/stack-radar/stackradar/pkg/detector"
+	"github.com/stack-radar/stackradar/pkg/lockfile"
+)
+
+var (
+	lockPath      string
+	lockFile      string
+	lockRegistry  string
+	lockManifests []string
+	lockPlatform  string
+)
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Record image digests in stackradar.lock",
+	Long: `Detects the tech stack and records the content digest of each generated image tag
+in stackradar.lock, so that get emits digest-pinned references such as
+python:3.12-slim@sha256:...
+
+Digests are read from a registry mirror (--registry) or from saved
+"docker manifest inspect -v <image>" output (--manifest).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lockRegistry == "" && len(lockManifests) == 0 {
+			return fmt.Errorf("either --registry or --manifest is required")
+		}
+
+		var resolvers []lockfile.Resolver
+		if len(lockManifests) > 0 {
+			manifests, err := lockfile.LoadManifests(lockPlatform, lockManifests...)
+			if err != nil {
+				return err
+			}
+			resolvers = append(resolvers, manifests)
+		}
+		if lockRegistry != "" {
+			resolvers = append(resolvers, lockfile.NewRegistryResolver(lockRegistry))
+		}
+
+		result, err := detector.NewDetector().Detect(lockPath)
+		if err != nil {
+			return fmt.Errorf("detection failed: %w", err)
+		}
+
+		file := lockFile
+		if file == "" {
+			file = filepath.Join(lockPath, lockfile.FileName)
+		}
+		lock, err := lockfile.Load(file)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			lock = lockfile.New()
+		}
+
+		images := lockfile.Images(result)
+		for _, err := range lock.Refresh(images, resolvers...) {
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", err)
+		}
+		if err := lock.Save(file); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Locked %d of %d images in %s\n", len(lock.Images), len(images), file)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lockCmd)
+
+	lockCmd.Flags().StringVarP(&lockPath, "path", "p", ".", "Local repository path")
+	lockCmd.Flags().StringVar(&lockFile, "file", "", "Lock file (default <path>/stackradar.lock)")
+	lockCmd.Flags().StringVar(&lockRegistry, "registry", "", "Registry mirror URL, e.g. http://localhost:5000")
+	lockCmd.Flags().StringArrayVar(&lockManifests, "manifest", nil, "File or directory of docker manifest inspect -v output")
+	lockCmd.Flags().StringVar(&lockPlatform, "platform", "linux/amd64", "Platform to pin for multi-platform manifests")
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/stack-radar/stackradar/pkg/detector"
+	"github.com/stack-radar/stackradar/pkg/lifecycle"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	outdatedPath      string
+	outdatedFormat    string
+	outdatedRecursive bool
+	outdatedLifecycle string
+)
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List runtime upgrade candidates",
+	Long: `Lists projects whose declared runtime version has a newer supported release or
+newer LTS release, or reached end of life, together with the files that pin the
+version. Use --recursive to check every project in a monorepo.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d := detector.NewDetector()
+		if outdatedLifecycle != "" {
+			l, err := lifecycle.Load(outdatedLifecycle)
+			if err != nil {
+				return err
+			}
+			d.SetLifecycle(l)
+		}
+
+		upgrades, err := d.Outdated(outdatedPath, outdatedRecursive)
+		if err != nil {
+			return fmt.Errorf("detection failed: %w", err)
+		}
+
+		switch outdatedFormat {
+		case "json":
+			data, err := json.MarshalIndent(upgrades, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		case "yaml":
+			data, err := yaml.Marshal(upgrades)
+			if err != nil {
+				return fmt.Errorf("failed to marshal YAML: %w", err)
+			}
+			fmt.Print(string(data))
+		default: // table
+			if len(upgrades) == 0 {
+				fmt.Println("✅ All declared runtime versions are up to date")
+				return nil
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "PROJECT\tRUNTIME\tVERSION\tLATEST\tLATEST LTS\tEOL\tFILES")
+			for _, u := range upgrades {
+				eol := "no"
+				if u.EOL {
+					eol = "yes"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", u.Project, u.Runtime, u.Version,
+					orDash(u.Latest), orDash(u.LatestLTS), eol, orDash(strings.Join(u.Files, ", ")))
+			}
+			w.Flush()
+		}
+		return nil
+	},
+}
+
+// orDash shows empty table cells as a dash
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func init() {
+	rootCmd.AddCommand(outdatedCmd)
+
+	outdatedCmd.Flags().StringVarP(&outdatedPath, "path", "p", ".", "Local repository path")
+	outdatedCmd.Flags().StringVarP(&outdatedFormat, "format", "f", "table", "Output format (table, yaml, json)")
+	outdatedCmd.Flags().BoolVarP(&outdatedRecursive, "recursive", "r", false, "Check every project in a monorepo")
+	outdatedCmd.Flags().StringVar(&outdatedLifecycle, "lifecycle", "", "Runtime lifecycle JSON replacing the embedded one")
+}
@@ -15,7 +15,9 @@ var rootCmd = &cobra.Command{
 	Long: `StackRadar analyzes repositories to detect programming languages,
 versions, build tools, and generates appropriate CI/CD Docker image tags.
 
-Supports: Python, Java, Kotlin, Node.js, Go, Rust, Ruby, PHP, .NET, Swift, Scala`,
+Supports: Python, Java, Kotlin, Node.js, Deno, Bun, Go, Rust, Ruby, PHP, .NET,
+Swift, Scala, Elixir, Erlang, Dart, Flutter, C, C++, Haskell, Clojure, OCaml,
+Zig, Nim, Crystal, R, Julia, Perl, Lua, Shell`,
 	Version: version,
 }
 
@@ -0,0 +1,168 @@
+package catalog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/version"
+)
+
+//go:embed catalog.json
+var embedded []byte
+
+// ErrNoMatchingTag is returned when no published tag matches a version
+var ErrNoMatchingTag = errors.New("no matching image tag")
+
+// Catalog lists the published tags of the images stackradar generates, keyed by
+// repository. Versions are the values substituted into an image template, so
+// "3.12" stands for python:3.12-slim as well as python:3.12-alpine.
+type Catalog struct {
+	Updated string           `json:"updated"`
+	Images  map[string]Image `json:"images"`
+}
+
+// Image lists the known versions of an image repository
+type Image struct {
+	Versions []string `json:"versions"`
+}
+
+// Default returns the catalog embedded in the binary
+func Default() *Catalog {
+	c, err := Parse(embedded)
+	if err != nil {
+		panic(fmt.Sprintf("embedded image catalog: %v", err))
+	}
+	return c
+}
+
+// Load reads a catalog from a JSON file, e.g. one refreshed after a release
+func Load(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+	c, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse catalog %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Parse decodes a catalog from JSON
+func Parse(data []byte) (*Catalog, error) {
+	var c Catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Resolve formats an image template with a version, snapping the version to the
+// nearest published tag: 3.12.4 becomes the floating 3.12 tag, and 8 becomes 8.0
+// when only minor tags are published. Repositories missing from the catalog are
+// not validated, nor are unversioned templates such as gcr.io/distroless/static.
+// When no tag matches, the unsnapped tag is returned together with an error
+// wrapping ErrNoMatchingTag.
+func (c *Catalog) Resolve(template, version string) (string, error) {
+	if !strings.Contains(template, "%s") {
+		return template, nil
+	}
+	if c == nil {
+		return fmt.Sprintf(template, version), nil
+	}
+	repository := Repository(template)
+	image, ok := c.Images[repository]
+	if !ok {
+		return fmt.Sprintf(template, version), nil
+	}
+	if snapped, ok := image.match(version); ok {
+		return fmt.Sprintf(template, snapped), nil
+	}
+	return fmt.Sprintf(template, version), fmt.Errorf("%w: %s has no tag for version %s", ErrNoMatchingTag, repository, version)
+}
+
+// Repository returns the repository part of an image template, e.g. "python" for
+// "python:%s-slim"
+func Repository(template string) string {
+	prefix, _, _ := strings.Cut(template, "%s")
+	if idx := strings.LastIndex(prefix, ":"); idx > strings.LastIndex(prefix, "/") {
+		return prefix[:idx]
+	}
+	return prefix
+}
+
+// numericPrefix matches the dotted numeric part of a version, e.g. 3.12.4 in 3.12.4rc1
+var numericPrefix = regexp.MustCompile(`^\d+(?:\.\d+)*`)
+
+// match finds the published version closest to the requested one. An exact
+// match wins, then the newest tag the version is a prefix of (8 -> 8.0), then a
+// less specific tag when the image publishes none as precise as the version
+// (3.12.4 -> 3.12, 20.11.1 -> 20). A version missing at a precision the image
+// does publish, such as Go 1.19 or Terraform 1.9.3, does not match. The stable
+// channel of toolchains such as Rust matches the newest published version.
+func (i Image) match(version string) (string, bool) {
+	if i.has(version) {
+		return version, true
+	}
+	if version == "stable" {
+		newest := i.newest("")
+		return newest, newest != ""
+	}
+
+	candidate := numericPrefix.FindString(version)
+	if candidate == "" {
+		return "", false
+	}
+	if newest := i.newest(candidate + "."); newest != "" {
+		return newest, true
+	}
+	for {
+		if i.has(candidate) {
+			return candidate, true
+		}
+		if i.hasPrecision(strings.Count(candidate, ".")) {
+			return "", false
+		}
+		idx := strings.LastIndex(candidate, ".")
+		if idx < 0 {
+			return "", false
+		}
+		candidate = candidate[:idx]
+	}
+}
+
+// has reports whether a version is published
+func (i Image) has(version string) bool {
+	for _, v := range i.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPrecision reports whether any published version has the given number of dots
+func (i Image) hasPrecision(dots int) bool {
+	for _, v := range i.Versions {
+		if strings.Count(v, ".") == dots {
+			return true
+		}
+	}
+	return false
+}
+
+// newest returns the highest published version starting with prefix
+func (i Image) newest(prefix string) string {
+	var newest string
+	for _, v := range i.Versions {
+		if strings.HasPrefix(v, prefix) && (newest == "" || version.Compare(v, newest) > 0) {
+			newest = v
+		}
+	}
+	return newest
+}
@@ -0,0 +1,106 @@
+package catalog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	c := &Catalog{Images: map[string]Image{
+		"python":                       {Versions: []string{"3", "3.11", "3.12"}},
+		"node":                         {Versions: []string{"20", "22"}},
+		"mcr.microsoft.com/dotnet/sdk": {Versions: []string{"8.0", "9.0"}},
+		"hashicorp/terraform":          {Versions: []string{"1.9.0", "1.9.8", "1.10.5"}},
+		"rust":                         {Versions: []string{"1", "1.89", "1.90"}},
+	}}
+
+	tests := []struct {
+		name     string
+		template string
+		version  string
+		expected string
+		err      bool
+	}{
+		{"Exact tag", "python:%s-slim", "3.12", "python:3.12-slim", false},
+		{"Patch snaps to minor", "python:%s-slim", "3.12.4", "python:3.12-slim", false},
+		{"Unknown minor does not snap to major", "python:%s-alpine", "3.9.1", "python:3.9.1-alpine", true},
+		{"Prerelease snaps to minor", "python:%s-slim", "3.12.0rc1", "python:3.12-slim", false},
+		{"Release snaps to major line", "node:%s-alpine", "20.11.1", "node:20-alpine", false},
+		{"Major expands to minor", "mcr.microsoft.com/dotnet/sdk:%s-alpine", "8", "mcr.microsoft.com/dotnet/sdk:8.0-alpine", false},
+		{"Minor expands to newest patch", "hashicorp/terraform:%s", "1.9", "hashicorp/terraform:1.9.8", false},
+		{"Missing patch is not replaced", "hashicorp/terraform:%s", "1.9.3", "hashicorp/terraform:1.9.3", true},
+		{"No matching tag", "mcr.microsoft.com/dotnet/sdk:%s-alpine", "5.0", "mcr.microsoft.com/dotnet/sdk:5.0-alpine", true},
+		{"Stable channel is the newest tag", "rust:%s-alpine", "stable", "rust:1.90-alpine", false},
+		{"Unknown repository", "golang:%s-alpine", "1.99", "golang:1.99-alpine", false},
+		{"Unversioned template", "gcr.io/distroless/static", "1.22", "gcr.io/distroless/static", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := c.Resolve(tt.template, tt.version)
+			if tag != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, tag)
+			}
+			if tt.err != (err != nil) {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+			if err != nil && !errors.Is(err, ErrNoMatchingTag) {
+				t.Errorf("Expected ErrNoMatchingTag, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRepository(t *testing.T) {
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"python:%s-slim", "python"},
+		{"ocaml/opam:debian-ocaml-%s", "ocaml/opam"},
+		{"mcr.microsoft.com/dotnet/sdk:%s-alpine", "mcr.microsoft.com/dotnet/sdk"},
+		{"localhost:5000/tools/zig:%s", "localhost:5000/tools/zig"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			if got := Repository(tt.template); got != tt.expected {
+				t.Errorf("Repository(%q) = %q, expected %q", tt.template, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	file := filepath.Join(tmpDir, "catalog.json")
+	if err := os.WriteFile(file, []byte(`{"updated": "2026-10-01", "images": {"python": {"versions": ["3.13"]}}}`), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	c, err := Load(file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tag, _ := c.Resolve("python:%s-slim", "3.13.1"); tag != "python:3.13-slim" {
+		t.Errorf("Expected %q, got %q", "python:3.13-slim", tag)
+	}
+
+	if _, err := Load(filepath.Join(tmpDir, "missing.json")); err == nil {
+		t.Error("Expected error for missing catalog")
+	}
+}
+
+func TestDefault(t *testing.T) {
+	c := Default()
+	if c.Updated == "" || len(c.Images) == 0 {
+		t.Fatalf("Expected embedded catalog to have images, got %+v", c)
+	}
+}
@@ -4,94 +4,408 @@ package detector
 type LanguageConfig struct {
 	Name           string
 	FileIndicators []string
-	ImageTemplate  string
+	Priority       int // Languages sharing file indicators are checked in descending priority
+
+	// FallbackIndicators are only checked once no language matched its FileIndicators,
+	// e.g. loose C sources that also appear in Python or Ruby native extensions
+	FallbackIndicators []string
+
+	// MakefileSources detect Makefile-driven projects, whose sources with these
+	// extensions may sit in any directory, e.g. lib/x.c. They are checked before
+	// FallbackIndicators.
+	MakefileSources []string
+
+	Runtime        string // Runtime that executes the language, e.g. "jvm" for Kotlin
+	ImageTemplate  string // Formatted with the runtime version
+	DefaultVersion string // Default runtime version
+
+	// BuildToolImageTemplates override ImageTemplate for specific build tools
+	BuildToolImageTemplates map[string]string
+
+	// Compilers replace Runtime, ImageTemplate and DefaultVersion when the project
+	// selects another compiler, e.g. Clang for C and C++
+	Compilers map[string]CompilerConfig
+
+	// RuntimeImageTemplate is a slim image that only runs the built application, e.g. a
+	// JRE instead of a JDK. Languages without one run in their CI image.
+	RuntimeImageTemplate string
+
+	// AppTypeRuntimeImageTemplates override RuntimeImageTemplate for web, worker or cli apps
+	AppTypeRuntimeImageTemplates map[string]string
+
+	// GlibcImageTemplate replaces a musl (Alpine) ImageTemplate when native dependencies need glibc
+	GlibcImageTemplate string
+
+	// Some images are versioned by the language rather than its runtime (elixir:1.15 runs on OTP)
+	ImageFromLanguageVersion bool
+	DefaultLanguageVersion   string
+
+	// VersionFiles may pin the runtime version and need changing on upgrades
+	VersionFiles []string
+}
+
+// CompilerConfig defines the image of an alternative compiler
+type CompilerConfig struct {
+	ImageTemplate  string // Formatted with the compiler version
 	DefaultVersion string
 }
 
+// clangCompiler builds C and C++ projects that select Clang
+var clangCompiler = CompilerConfig{ImageTemplate: "silkeh/clang:%s", DefaultVersion: "19"}
+
 // Config holds all language configurations
 var Config = map[string]LanguageConfig{
 	"python": {
 		Name:           "python",
 		FileIndicators: []string{"requirements.txt", "setup.py", "pyproject.toml", "Pipfile"},
+		Runtime:        "python",
 		ImageTemplate:  "python:%s-slim",
 		DefaultVersion: "3.12",
+		VersionFiles:   []string{".python-version", "pyproject.toml", "runtime.txt", "Pipfile"},
 	},
 	"java": {
-		Name:           "java",
-		FileIndicators: []string{"pom.xml", "build.gradle", "build.gradle.kts"},
-		ImageTemplate:  "eclipse-temurin:%s-jdk-alpine",
-		DefaultVersion: "17",
+		Name:                 "java",
+		FileIndicators:       []string{"pom.xml", "build.gradle", "build.gradle.kts"},
+		Runtime:              "jvm",
+		ImageTemplate:        "eclipse-temurin:%s-jdk-alpine",
+		DefaultVersion:       "17",
+		VersionFiles:         []string{"pom.xml", "build.gradle", "build.gradle.kts", ".java-version", ".sdkmanrc"},
+		RuntimeImageTemplate: "eclipse-temurin:%s-jre-alpine",
 	},
 	"kotlin": {
-		Name:           "kotlin",
-		FileIndicators: []string{"build.gradle.kts"},
-		ImageTemplate:  "eclipse-temurin:%s-jdk-alpine",
-		DefaultVersion: "17",
+		Name:                 "kotlin",
+		FileIndicators:       []string{"build.gradle.kts"},
+		Runtime:              "jvm",
+		ImageTemplate:        "eclipse-temurin:%s-jdk-alpine",
+		DefaultVersion:       "17",
+		VersionFiles:         []string{"build.gradle.kts", ".java-version", ".sdkmanrc"},
+		RuntimeImageTemplate: "eclipse-temurin:%s-jre-alpine",
 	},
 	"node": {
-		Name:           "node",
-		FileIndicators: []string{"package.json"},
-		ImageTemplate:  "node:%s-alpine",
-		DefaultVersion: "20",
+		Name:               "node",
+		FileIndicators:     []string{"package.json"},
+		Priority:           1,
+		Runtime:            "node",
+		ImageTemplate:      "node:%s-alpine",
+		DefaultVersion:     "20",
+		GlibcImageTemplate: "node:%s-bookworm-slim",
+		VersionFiles:       []string{".nvmrc", ".node-version", "package.json"},
 	},
 	"javascript": {
-		Name:           "javascript",
-		FileIndicators: []string{"package.json"},
-		ImageTemplate:  "node:%s-alpine",
-		DefaultVersion: "20",
+		Name:               "javascript",
+		FileIndicators:     []string{"package.json"},
+		Runtime:            "node",
+		ImageTemplate:      "node:%s-alpine",
+		DefaultVersion:     "20",
+		GlibcImageTemplate: "node:%s-bookworm-slim",
+		VersionFiles:       []string{".nvmrc", ".node-version", "package.json"},
 	},
 	"typescript": {
-		Name:           "typescript",
-		FileIndicators: []string{"tsconfig.json", "package.json"},
-		ImageTemplate:  "node:%s-alpine",
-		DefaultVersion: "20",
+		Name:               "typescript",
+		FileIndicators:     []string{"tsconfig.json"},
+		Priority:           2,
+		Runtime:            "node",
+		ImageTemplate:      "node:%s-alpine",
+		DefaultVersion:     "20",
+		GlibcImageTemplate: "node:%s-bookworm-slim",
+		VersionFiles:       []string{".nvmrc", ".node-version", "package.json"},
+	},
+	"deno": {
+		Name:           "deno",
+		FileIndicators: []string{"deno.json", "deno.jsonc", "deno.lock"},
+		Priority:       3,
+		Runtime:        "deno",
+		ImageTemplate:  "denoland/deno:%s",
+		DefaultVersion: "2.0.0",
+		VersionFiles:   []string{".dvmrc"},
+	},
+	"bun": {
+		Name:           "bun",
+		FileIndicators: []string{"bun.lockb", "bun.lock", "bunfig.toml", ".bun-version"},
+		Priority:       3,
+		Runtime:        "bun",
+		ImageTemplate:  "oven/bun:%s",
+		DefaultVersion: "1.1",
+		VersionFiles:   []string{".bun-version", "package.json"},
 	},
 	"go": {
-		Name:           "go",
-		FileIndicators: []string{"go.mod"},
-		ImageTemplate:  "golang:%s-alpine",
-		DefaultVersion: "1.22",
+		Name:                 "go",
+		FileIndicators:       []string{"go.mod"},
+		Runtime:              "go",
+		ImageTemplate:        "golang:%s-alpine",
+		DefaultVersion:       "1.22",
+		VersionFiles:         []string{"go.mod"},
+		RuntimeImageTemplate: "gcr.io/distroless/static",
 	},
 	"rust": {
-		Name:           "rust",
-		FileIndicators: []string{"Cargo.toml"},
-		ImageTemplate:  "rust:%s-alpine",
-		DefaultVersion: "1.75",
+		Name:                 "rust",
+		FileIndicators:       []string{"Cargo.toml"},
+		Runtime:              "rust",
+		ImageTemplate:        "rust:%s-alpine",
+		DefaultVersion:       "1.75",
+		RuntimeImageTemplate: "gcr.io/distroless/static",
 	},
 	"ruby": {
-		Name:           "ruby",
-		FileIndicators: []string{"Gemfile"},
-		ImageTemplate:  "ruby:%s-alpine",
-		DefaultVersion: "3.3",
+		Name:               "ruby",
+		FileIndicators:     []string{"Gemfile"},
+		Runtime:            "ruby",
+		ImageTemplate:      "ruby:%s-alpine",
+		DefaultVersion:     "3.3",
+		GlibcImageTemplate: "ruby:%s-slim",
+		VersionFiles:       []string{".ruby-version", "Gemfile"},
 	},
 	"php": {
 		Name:           "php",
 		FileIndicators: []string{"composer.json"},
+		Runtime:        "php",
 		ImageTemplate:  "php:%s-cli-alpine",
 		DefaultVersion: "8.3",
+		VersionFiles:   []string{"composer.json"},
+		AppTypeRuntimeImageTemplates: map[string]string{
+			"web": "php:%s-fpm-alpine",
+		},
 	},
 	"dotnet": {
-		Name:           "dotnet",
-		FileIndicators: []string{"*.csproj", "*.sln", "*.slnx", "*/*.csproj"},
-		ImageTemplate:  "mcr.microsoft.com/dotnet/sdk:%s-alpine",
-		DefaultVersion: "8.0",
+		Name:                 "dotnet",
+		FileIndicators:       []string{"*.csproj", "*.sln", "*.slnx", "*/*.csproj"},
+		Priority:             1,
+		Runtime:              "dotnet",
+		ImageTemplate:        "mcr.microsoft.com/dotnet/sdk:%s-alpine",
+		DefaultVersion:       "8.0",
+		VersionFiles:         []string{"global.json", "*.csproj", "*/*.csproj", "Directory.Build.props"},
+		RuntimeImageTemplate: "mcr.microsoft.com/dotnet/runtime:%s",
+		AppTypeRuntimeImageTemplates: map[string]string{
+			"web": "mcr.microsoft.com/dotnet/aspnet:%s",
+		},
 	},
 	"csharp": {
-		Name:           "csharp",
-		FileIndicators: []string{"*.csproj", "*.sln", "*.slnx", "*/*.csproj"},
-		ImageTemplate:  "mcr.microsoft.com/dotnet/sdk:%s-alpine",
-		DefaultVersion: "8.0",
+		Name:                 "csharp",
+		FileIndicators:       []string{"*.csproj", "*.sln", "*.slnx", "*/*.csproj"},
+		Runtime:              "dotnet",
+		ImageTemplate:        "mcr.microsoft.com/dotnet/sdk:%s-alpine",
+		DefaultVersion:       "8.0",
+		VersionFiles:         []string{"global.json", "*.csproj", "*/*.csproj", "Directory.Build.props"},
+		RuntimeImageTemplate: "mcr.microsoft.com/dotnet/runtime:%s",
+		AppTypeRuntimeImageTemplates: map[string]string{
+			"web": "mcr.microsoft.com/dotnet/aspnet:%s",
+		},
 	},
 	"swift": {
-		Name:           "swift",
-		FileIndicators: []string{"Package.swift"},
-		ImageTemplate:  "swift:%s",
-		DefaultVersion: "5.9",
+		Name:                 "swift",
+		FileIndicators:       []string{"Package.swift"},
+		Runtime:              "swift",
+		ImageTemplate:        "swift:%s",
+		DefaultVersion:       "5.9",
+		RuntimeImageTemplate: "swift:%s-slim",
 	},
 	"scala": {
-		Name:           "scala",
-		FileIndicators: []string{"build.sbt"},
-		ImageTemplate:  "eclipse-temurin:%s-jdk-alpine",
-		DefaultVersion: "17",
+		Name:                 "scala",
+		FileIndicators:       []string{"build.sbt"},
+		Runtime:              "jvm",
+		ImageTemplate:        "eclipse-temurin:%s-jdk-alpine",
+		DefaultVersion:       "17",
+		VersionFiles:         []string{"build.sbt", ".java-version", ".sdkmanrc"},
+		RuntimeImageTemplate: "eclipse-temurin:%s-jre-alpine",
+	},
+	"elixir": {
+		Name:                     "elixir",
+		FileIndicators:           []string{"mix.exs"},
+		Priority:                 1,
+		Runtime:                  "otp",
+		ImageTemplate:            "elixir:%s-alpine",
+		DefaultVersion:           "26",
+		ImageFromLanguageVersion: true,
+		DefaultLanguageVersion:   "1.16",
+		VersionFiles:             []string{"mix.exs"},
+	},
+	"erlang": {
+		Name:           "erlang",
+		FileIndicators: []string{"rebar.config", "rebar.lock"},
+		Runtime:        "otp",
+		ImageTemplate:  "erlang:%s-alpine",
+		DefaultVersion: "26",
+		VersionFiles:   []string{"rebar.config"},
+	},
+	"dart": {
+		Name:           "dart",
+		FileIndicators: []string{"pubspec.yaml"},
+		Runtime:        "dart",
+		ImageTemplate:  "dart:%s",
+		DefaultVersion: "3.3",
+	},
+	"flutter": {
+		Name:           "flutter",
+		FileIndicators: []string{".fvmrc", ".fvm/fvm_config.json"},
+		Priority:       1,
+		Runtime:        "flutter",
+		ImageTemplate:  "ghcr.io/cirruslabs/flutter:%s",
+		DefaultVersion: "stable",
+	},
+	"haskell": {
+		Name:           "haskell",
+		FileIndicators: []string{"stack.yaml", "cabal.project", "*.cabal"},
+		Runtime:        "ghc",
+		ImageTemplate:  "haskell:%s",
+		DefaultVersion: "9.6",
+	},
+	"clojure": {
+		Name:           "clojure",
+		FileIndicators: []string{"project.clj", "deps.edn"},
+		Runtime:        "jvm",
+		ImageTemplate:  "clojure:temurin-%s-tools-deps",
+		DefaultVersion: "21",
+		BuildToolImageTemplates: map[string]string{
+			"lein": "clojure:temurin-%s-lein",
+		},
+		VersionFiles:         []string{"project.clj", "deps.edn", ".java-version", ".sdkmanrc"},
+		RuntimeImageTemplate: "eclipse-temurin:%s-jre-alpine",
+	},
+	"ocaml": {
+		Name:           "ocaml",
+		FileIndicators: []string{"dune-project", "*.opam"},
+		Runtime:        "ocaml",
+		ImageTemplate:  "ocaml/opam:debian-ocaml-%s",
+		DefaultVersion: "5.1",
+	},
+	"zig": {
+		Name:           "zig",
+		FileIndicators: []string{"build.zig", "build.zig.zon"},
+		Runtime:        "zig",
+		ImageTemplate:  "euantorano/zig:%s",
+		DefaultVersion: "0.13.0",
+	},
+	"nim": {
+		Name:           "nim",
+		FileIndicators: []string{"*.nimble"},
+		Runtime:        "nim",
+		ImageTemplate:  "nimlang/nim:%s-alpine",
+		DefaultVersion: "2.0.8",
+	},
+	"crystal": {
+		Name:           "crystal",
+		FileIndicators: []string{"shard.yml"},
+		Runtime:        "crystal",
+		ImageTemplate:  "crystallang/crystal:%s-alpine",
+		DefaultVersion: "1.13.1",
+	},
+	"r": {
+		Name:           "r",
+		FileIndicators: []string{"renv.lock", "DESCRIPTION", "*.Rproj"},
+		Runtime:        "r",
+		ImageTemplate:  "rocker/r-ver:%s",
+		DefaultVersion: "4.4",
+	},
+	"julia": {
+		Name:           "julia",
+		FileIndicators: []string{"Project.toml", "Manifest.toml", "JuliaProject.toml"},
+		Runtime:        "julia",
+		ImageTemplate:  "julia:%s",
+		DefaultVersion: "1.10",
+	},
+	"perl": {
+		Name:               "perl",
+		FileIndicators:     []string{"cpanfile", "Makefile.PL", "Build.PL", "dist.ini", ".perl-version"},
+		FallbackIndicators: []string{"*.pl", "bin/*.pl", "script/*.pl"},
+		Runtime:            "perl",
+		ImageTemplate:      "perl:%s-slim",
+		DefaultVersion:     "5.38",
+		VersionFiles:       []string{".perl-version"},
+	},
+	"lua": {
+		Name:               "lua",
+		FileIndicators:     []string{"*.rockspec", "rockspecs/*.rockspec", ".luarc.json", ".luarc.jsonc"},
+		FallbackIndicators: []string{"*.lua"},
+		Runtime:            "lua",
+		ImageTemplate:      "nickblah/lua:%s-alpine",
+		DefaultVersion:     "5.4",
+	},
+	"bash": {
+		Name:               "bash",
+		FallbackIndicators: []string{"*.sh", "*.bash", "bin/*.sh", "scripts/*.sh"},
+		Runtime:            "bash",
+		ImageTemplate:      "bash:%s",
+		DefaultVersion:     "5.2",
+	},
+	"zsh": {
+		Name:               "zsh",
+		FallbackIndicators: []string{"*.zsh"},
+		Runtime:            "zsh",
+		ImageTemplate:      "zshusers/zsh:%s",
+		DefaultVersion:     "5.9",
+	},
+	"sh": {
+		Name:           "sh",
+		Runtime:        "alpine",
+		ImageTemplate:  "alpine:%s",
+		DefaultVersion: "3.20",
+	},
+	// C and C++ build files also drive native extensions of other languages, so
+	// every other manifest is checked first
+	"c": {
+		Name:               "c",
+		FileIndicators:     []string{"CMakeLists.txt", "meson.build", "conanfile.txt", "conanfile.py", "vcpkg.json"},
+		Priority:           -1,
+		FallbackIndicators: []string{"*.c", "src/*.c"},
+		MakefileSources:    []string{".c"},
+		Runtime:            "gcc",
+		ImageTemplate:      "gcc:%s",
+		DefaultVersion:     "13",
+		Compilers:          map[string]CompilerConfig{"clang": clangCompiler},
+	},
+	"cpp": {
+		Name:               "cpp",
+		FileIndicators:     []string{"CMakeLists.txt", "meson.build", "conanfile.txt", "conanfile.py", "vcpkg.json"},
+		Priority:           -1,
+		FallbackIndicators: []string{"*.cpp", "*.cc", "src/*.cpp", "src/*.cc"},
+		MakefileSources:    []string{".cpp", ".cc", ".cxx", ".c++"},
+		Runtime:            "gcc",
+		ImageTemplate:      "gcc:%s",
+		DefaultVersion:     "13",
+		Compilers:          map[string]CompilerConfig{"clang": clangCompiler},
+	},
+}
+
+// LinguistAliases maps GitHub Linguist language names to configured language names
+var LinguistAliases = map[string]string{
+	"c++":   "cpp",
+	"c#":    "csharp",
+	"shell": "bash",
+}
+
+// IaCToolConfig defines the configuration for an infrastructure-as-code tool
+type IaCToolConfig struct {
+	Name           string
+	FileIndicators []string
+	Supersedes     []string // Tools replaced by this one when both match, e.g. Terraform by OpenTofu
+	ImageTemplate  string
+	DefaultVersion string
+}
+
+// IaCConfig holds all infrastructure-as-code tool configurations
+var IaCConfig = map[string]IaCToolConfig{
+	"terraform": {
+		Name:           "terraform",
+		FileIndicators: []string{"*.tf", ".terraform-version", "*/*.tf"},
+		ImageTemplate:  "hashicorp/terraform:%s",
+		DefaultVersion: "1.9",
+	},
+	"opentofu": {
+		Name:           "opentofu",
+		FileIndicators: []string{".opentofu-version", "*.tofu", "*/*.tofu"},
+		Supersedes:     []string{"terraform"},
+		ImageTemplate:  "ghcr.io/opentofu/opentofu:%s",
+		DefaultVersion: "1.8",
+	},
+	"pulumi": {
+		Name:           "pulumi",
+		FileIndicators: []string{"Pulumi.yaml", "Pulumi.yml"},
+		ImageTemplate:  "pulumi/pulumi:%s",
+		DefaultVersion: "3.130.0",
+	},
+	"helm": {
+		Name:           "helm",
+		FileIndicators: []string{"Chart.yaml", "*/Chart.yaml", "charts/*/Chart.yaml"},
+		ImageTemplate:  "alpine/helm:%s",
+		DefaultVersion: "3.15.4",
 	},
 }
@@ -6,8 +6,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/stack-radar/stackradar/pkg/catalog"
+	"github.com/stack-radar/stackradar/pkg/lifecycle"
 	"github.com/stack-radar/stackradar/pkg/models"
 	"github.com/stack-radar/stackradar/pkg/parsers"
 )
@@ -16,6 +21,9 @@ import (
 type Detector struct {
 	linguistAvailable bool
 	config            map[string]LanguageConfig
+	iacConfig         map[string]IaCToolConfig
+	catalog           *catalog.Catalog
+	lifecycle         *lifecycle.Lifecycle
 }
 
 // NewDetector creates a new Detector instance
@@ -23,9 +31,22 @@ func NewDetector() *Detector {
 	return &Detector{
 		linguistAvailable: checkLinguist(),
 		config:            Config,
+		iacConfig:         IaCConfig,
+		catalog:           catalog.Default(),
+		lifecycle:         lifecycle.Default(),
 	}
 }
 
+// SetCatalog replaces the embedded image catalog, e.g. with a refreshed copy
+func (d *Detector) SetCatalog(c *catalog.Catalog) {
+	d.catalog = c
+}
+
+// SetLifecycle replaces the embedded runtime lifecycle data, e.g. with a refreshed copy
+func (d *Detector) SetLifecycle(l *lifecycle.Lifecycle) {
+	d.lifecycle = l
+}
+
 // LinguistAvailable returns whether GitHub Linguist is available
 func (d *Detector) LinguistAvailable() bool {
 	return d.linguistAvailable
@@ -48,39 +69,195 @@ func (d *Detector) Detect(path string) (*models.TechStack, error) {
 		return nil, fmt.Errorf("path does not exist: %s", path)
 	}
 
+	// Infrastructure-only repositories have no programming language of their own
+	iac, warnings := d.detectIaC(absPath)
+
 	// 1. Detect language
 	language, err := d.detectLanguage(absPath)
 	if err != nil {
+		if len(iac) > 0 {
+			return &models.TechStack{IaC: iac, Warnings: warnings}, nil
+		}
 		return nil, err
 	}
+	language = parsers.RefineLanguage(absPath, language)
 
 	// 2. Detect build tool
 	buildTool := parsers.DetectBuildTool(absPath, language)
+	packageManager := parsers.DetectPackageManager(absPath, language)
 
-	// 3. Detect version
-	version := parsers.DetectVersion(absPath, language, buildTool)
-	if version == "" {
+	// 3. Detect language and runtime versions
+	cfg := d.config[language]
+	compiler, _ := parsers.DetectCompiler(absPath, language)
+	compilerCfg, hasCompiler := cfg.Compilers[compiler]
+	if hasCompiler {
+		cfg.Runtime, cfg.DefaultVersion = compiler, compilerCfg.DefaultVersion
+	}
+	languageVersion := parsers.DetectVersion(absPath, language, buildTool)
+	runtimeVersion := parsers.DetectRuntimeVersion(absPath, language, buildTool)
+	runtimeDetected := runtimeVersion != ""
+	if !runtimeDetected {
 		// Use default version from config or fallback to "latest"
-		if cfg, ok := d.config[language]; ok && cfg.DefaultVersion != "" {
-			version = cfg.DefaultVersion
+		if cfg.DefaultVersion != "" {
+			runtimeVersion = cfg.DefaultVersion
 		} else {
-			version = "latest"
+			runtimeVersion = "latest"
+		}
+	}
+	if languageVersion == "" && cfg.Runtime == language {
+		// The language is its own runtime (Python, Go, ...), so the versions match
+		languageVersion = runtimeVersion
+	}
+	if languageVersion == "" {
+		languageVersion = cfg.DefaultLanguageVersion
+	}
+
+	runtime := models.Runtime{Name: cfg.Runtime, Version: runtimeVersion}
+	if runtime.Name == "" {
+		runtime.Name = language
+	}
+
+	// Only versions the project declares are checked, not our own defaults
+	if cycle, ok := d.lifecycle.Lookup(runtime.Name, runtime.Version); ok && runtimeDetected {
+		now := time.Now()
+		eol := cycle.IsEOL(now)
+		runtime.EOL = &eol
+		runtime.EOLDate = cycle.EOL
+		runtime.Latest, runtime.LatestLTS = d.lifecycle.Upgrades(runtime.Name, cycle.Cycle, now)
+		if eol {
+			warnings = append(warnings, fmt.Sprintf("%s %s reached end of life on %s", runtime.Name, cycle.Cycle, cycle.EOL))
 		}
+	} else if runtimeDetected && !d.lifecycle.Covers(runtime.Name) {
+		// Say so rather than pass --fail-on-eol silently
+		warnings = append(warnings, fmt.Sprintf("no lifecycle data for %s %s, end of life not checked", runtime.Name, runtime.Version))
 	}
 
-	// 4. Generate CI image tag
-	ciImageTag := d.generateImageTag(language, version)
+	var toolchain *models.Toolchain
+	if name, version := parsers.DetectToolchain(absPath, language, buildTool); name != "" {
+		toolchain = &models.Toolchain{Name: name, Version: version}
+	}
+
+	// 4. Generate CI image tag from the runtime
+	imageVersion := runtime.Version
+	if cfg.ImageFromLanguageVersion && languageVersion != "" {
+		imageVersion = languageVersion
+	}
+	ciImageTag, err := d.generateImageTag(language, buildTool, imageVersion)
+	if hasCompiler {
+		ciImageTag, err = d.catalog.Resolve(compilerCfg.ImageTemplate, imageVersion)
+	}
+
+	// Native extensions often lack musl builds, so prefer a glibc image over Alpine
+	var imageVariant, imageReason string
+	if native := parsers.DetectNativeDependencies(absPath, language); len(native) > 0 {
+		imageVariant = "glibc"
+		imageReason = fmt.Sprintf("native dependencies need glibc: %s", strings.Join(native, ", "))
+		if cfg.GlibcImageTemplate != "" {
+			ciImageTag, err = d.catalog.Resolve(cfg.GlibcImageTemplate, imageVersion)
+		}
+	}
+	if err != nil {
+		warnings = append(warnings, err.Error())
+	}
+
+	// 5. Detect application and test frameworks
+	frameworks := parsers.DetectFrameworks(absPath, language)
+	testFrameworks := parsers.DetectTestFrameworks(absPath, language)
+
+	// 6. Pick a slim runtime image for deployment, falling back to the CI image
+	appType := parsers.DetectAppType(absPath, language, frameworks)
+	runtimeImageTag := ciImageTag
+	if template := d.runtimeImageTemplate(language, appType); template != "" {
+		// The CI and runtime images often share a repository, so report a missing tag once
+		if runtimeImageTag, err = d.catalog.Resolve(template, imageVersion); err != nil && !slices.Contains(warnings, err.Error()) {
+			warnings = append(warnings, err.Error())
+		}
+	}
+
+	// 7. Suggest install, build, test, lint and run commands
+	var commands *models.Commands
+	suggested := parsers.DetectCommands(absPath, language, buildTool, packageManager, testFrameworks)
+	if !suggested.IsEmpty() {
+		commands = &suggested
+	}
 
 	return &models.TechStack{
 		Language: models.Language{
-			Name:       language,
-			Version:    version,
-			BuildTool:  buildTool,
-			CIImageTag: ciImageTag,
+			Name:            language,
+			LanguageVersion: languageVersion,
+			Runtime:         runtime,
+			Toolchain:       toolchain,
+			BuildTool:       buildTool,
+			PackageManager:  packageManager,
+			CIImageTag:      ciImageTag,
+			RuntimeImageTag: runtimeImageTag,
+			AppType:         appType,
+			ImageVariant:    imageVariant,
+			ImageReason:     imageReason,
 		},
+		Frameworks:     frameworks,
+		TestFrameworks: testFrameworks,
+		BuildCommand:   suggested.Build,
+		TestCommand:    suggested.Test,
+		Commands:       commands,
+		Services:       parsers.DetectServices(absPath, language),
+		IaC:            iac,
+		Warnings:       warnings,
 	}, nil
 }
 
+// detectIaC detects infrastructure-as-code tools, their versions and CI images.
+// Image tags missing from the catalog are reported as warnings.
+func (d *Detector) detectIaC(path string) ([]models.IaCTool, []string) {
+	names := make([]string, 0, len(d.iacConfig))
+	for name := range d.iacConfig {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	matched := make(map[string]bool)
+	for _, name := range names {
+		for _, filePattern := range d.iacConfig[name].FileIndicators {
+			if fileExists(path, filePattern) {
+				matched[name] = true
+				break
+			}
+		}
+	}
+	for name := range matched {
+		for _, superseded := range d.iacConfig[name].Supersedes {
+			delete(matched, superseded)
+		}
+	}
+
+	var tools []models.IaCTool
+	var warnings []string
+	for _, name := range names {
+		if !matched[name] {
+			continue
+		}
+		cfg := d.iacConfig[name]
+		version := parsers.DetectIaCVersion(path, name)
+		if version == "" {
+			version = cfg.DefaultVersion
+		}
+		imageTag, err := d.catalog.Resolve(cfg.ImageTemplate, version)
+		if err != nil {
+			warnings = append(warnings, err.Error())
+		}
+		tool := models.IaCTool{
+			Name:       name,
+			Version:    version,
+			CIImageTag: imageTag,
+		}
+		if name == "pulumi" {
+			tool.Language = parsers.DetectPulumiLanguage(path)
+		}
+		tools = append(tools, tool)
+	}
+	return tools, warnings
+}
+
 // detectLanguage tries multiple detection methods
 func (d *Detector) detectLanguage(path string) (string, error) {
 	// Try Linguist first if available
@@ -123,14 +300,33 @@ func (d *Detector) detectLanguageWithLinguist(path string) (string, error) {
 		}
 	}
 
-	return strings.ToLower(maxLang), nil
+	lang := strings.ToLower(maxLang)
+	if alias, ok := LinguistAliases[lang]; ok {
+		lang = alias
+	}
+	return lang, nil
 }
 
 // detectLanguageFallback uses configuration-based file pattern matching
 func (d *Detector) detectLanguageFallback(path string) (string, error) {
-	// Iterate through all configured languages
-	for langName, langConfig := range d.config {
-		for _, filePattern := range langConfig.FileIndicators {
+	// Iterate through all configured languages in a stable order
+	order := d.detectionOrder()
+	for _, langName := range order {
+		for _, filePattern := range d.config[langName].FileIndicators {
+			if fileExists(path, filePattern) {
+				return langName, nil
+			}
+		}
+	}
+
+	// Only consider weaker indicators once no project manifest matched
+	for _, langName := range order {
+		if sources := d.config[langName].MakefileSources; len(sources) > 0 && parsers.IsMakefileProject(path, sources...) {
+			return langName, nil
+		}
+	}
+	for _, langName := range order {
+		for _, filePattern := range d.config[langName].FallbackIndicators {
 			if fileExists(path, filePattern) {
 				return langName, nil
 			}
@@ -140,15 +336,50 @@ func (d *Detector) detectLanguageFallback(path string) (string, error) {
 	return "", fmt.Errorf("unable to detect programming language")
 }
 
-// generateImageTag creates Docker image tag from configuration
-func (d *Detector) generateImageTag(language, version string) string {
-	// Look up the image template from configuration
+// detectionOrder returns configured language names sorted by priority, then by name
+func (d *Detector) detectionOrder() []string {
+	names := make([]string, 0, len(d.config))
+	for name := range d.config {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		pi, pj := d.config[names[i]].Priority, d.config[names[j]].Priority
+		if pi != pj {
+			return pi > pj
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// generateImageTag creates Docker image tag from configuration using the runtime version.
+// The version is snapped to the nearest tag in the image catalog; the error reports
+// a version the catalog has no tag for.
+func (d *Detector) generateImageTag(language, buildTool, version string) (string, error) {
+	return d.catalog.Resolve(d.imageTemplate(language, buildTool), version)
+}
+
+// imageTemplate looks up the image template for a language and build tool
+func (d *Detector) imageTemplate(language, buildTool string) string {
 	if cfg, ok := d.config[language]; ok && cfg.ImageTemplate != "" {
-		return fmt.Sprintf(cfg.ImageTemplate, version)
+		if template, ok := cfg.BuildToolImageTemplates[buildTool]; ok {
+			return template
+		}
+		return cfg.ImageTemplate
 	}
 
 	// Default fallback for unconfigured languages
-	return fmt.Sprintf("%s:%s-alpine", language, version)
+	return language + ":%s-alpine"
+}
+
+// runtimeImageTemplate looks up the runtime image template for a language and app type.
+// An empty template means the application runs in its CI image.
+func (d *Detector) runtimeImageTemplate(language, appType string) string {
+	cfg := d.config[language]
+	if template, ok := cfg.AppTypeRuntimeImageTemplates[appType]; ok {
+		return template
+	}
+	return cfg.RuntimeImageTemplate
 }
 
 // fileExists checks if a file or pattern exists in the given path
@@ -3,7 +3,11 @@ package detector
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/stack-radar/stackradar/pkg/version"
 )
 
 func TestNewDetector(t *testing.T) {
@@ -65,6 +69,126 @@ func TestDetectLanguageFallback(t *testing.T) {
 			expected:    "rust",
 			shouldError: false,
 		},
+		{
+			name: "Elixir with mix.exs and rebar.config",
+			files: map[string]string{
+				"mix.exs":      "defmodule Web.MixProject do end",
+				"rebar.config": "{erl_opts, [debug_info]}.",
+			},
+			expected:    "elixir",
+			shouldError: false,
+		},
+		{
+			name: "Erlang with rebar.config",
+			files: map[string]string{
+				"rebar.config": "{erl_opts, [debug_info]}.",
+			},
+			expected:    "erlang",
+			shouldError: false,
+		},
+		{
+			name: "Dart with pubspec.yaml",
+			files: map[string]string{
+				"pubspec.yaml": "name: cli",
+			},
+			expected:    "dart",
+			shouldError: false,
+		},
+		{
+			name: "C with CMakeLists.txt",
+			files: map[string]string{
+				"CMakeLists.txt": "project(lib C)",
+			},
+			expected:    "c",
+			shouldError: false,
+		},
+		{
+			name: "Python native extension built with CMake",
+			files: map[string]string{
+				"pyproject.toml":   "[project]\nname = \"ext\"",
+				"requirements.txt": "numpy==1.26.4",
+				"CMakeLists.txt":   "project(ext C)",
+			},
+			expected:    "python",
+			shouldError: false,
+		},
+		{
+			name: "Rust crate with a CMake build",
+			files: map[string]string{
+				"Cargo.toml":     "[package]",
+				"CMakeLists.txt": "project(sys C)",
+			},
+			expected:    "rust",
+			shouldError: false,
+		},
+		{
+			name: "Go module with a CMake build",
+			files: map[string]string{
+				"go.mod":         "module example.com/test",
+				"CMakeLists.txt": "project(cgo C)",
+			},
+			expected:    "go",
+			shouldError: false,
+		},
+		{
+			name: "Makefile building nested C sources",
+			files: map[string]string{
+				"Makefile": "all:\n\tcc -o x lib/x.c",
+				"lib/x.c":  "int main(void) { return 0; }",
+			},
+			expected:    "c",
+			shouldError: false,
+		},
+		{
+			name: "Loose C sources do not outrank a manifest",
+			files: map[string]string{
+				"setup.py": "from setuptools import setup",
+				"ext.c":    "int f(void) { return 0; }",
+			},
+			expected:    "python",
+			shouldError: false,
+		},
+		{
+			name: "Loose C sources",
+			files: map[string]string{
+				"main.c": "int main(void) { return 0; }",
+			},
+			expected:    "c",
+			shouldError: false,
+		},
+		{
+			name: "Bun with package.json",
+			files: map[string]string{
+				"package.json": `{"name": "test"}`,
+				"bun.lockb":    "",
+			},
+			expected:    "bun",
+			shouldError: false,
+		},
+		{
+			name: "Deno with deno.json",
+			files: map[string]string{
+				"deno.json": `{"tasks": {}}`,
+			},
+			expected:    "deno",
+			shouldError: false,
+		},
+		{
+			name: "Perl with cpanfile",
+			files: map[string]string{
+				"cpanfile": "requires 'Mojolicious';",
+			},
+			expected:    "perl",
+			shouldError: false,
+		},
+		{
+			name: "Shell scripts",
+			files: map[string]string{
+				"deploy.sh": "#!/bin/bash",
+			},
+			expected:    "bash",
+			shouldError: false,
+		},
 		{
 			name:        "No language files",
 			files:       map[string]string{},
@@ -85,6 +209,9 @@ func TestDetectLanguageFallback(t *testing.T) {
 			// Create test files
 			for filename, content := range tt.files {
 				filePath := filepath.Join(tmpDir, filename)
+				if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+					t.Fatalf("Failed to create test dir: %v", err)
+				}
 				if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 					t.Fatalf("Failed to create test file: %v", err)
 				}
@@ -115,22 +242,45 @@ func TestGenerateImageTag(t *testing.T) {
 	detector := NewDetector()
 
 	tests := []struct {
-		language string
-		version  string
-		expected string
+		language  string
+		buildTool string
+		version   string
+		expected  string
 	}{
-		{"python", "3.12", "python:3.12-slim"},
-		{"go", "1.24", "golang:1.24-alpine"},
-		{"node", "20", "node:20-alpine"},
-		{"java", "17", "eclipse-temurin:17-jdk-alpine"},
-		{"rust", "1.75", "rust:1.75-alpine"},
-		{"dotnet", "8", "mcr.microsoft.com/dotnet/sdk:8-alpine"},
-		{"unknown", "1.0", "unknown:1.0-alpine"}, // fallback
+		{"python", "", "3.12", "python:3.12-slim"},
+		{"go", "", "1.24", "golang:1.24-alpine"},
+		{"node", "", "20", "node:20-alpine"},
+		{"java", "", "17", "eclipse-temurin:17-jdk-alpine"},
+		{"rust", "", "1.75", "rust:1.75-alpine"},
+		{"dotnet", "", "8", "mcr.microsoft.com/dotnet/sdk:8.0-alpine"}, // only minor tags are published
+		{"elixir", "", "1.15", "elixir:1.15-alpine"},
+		{"erlang", "", "26", "erlang:26-alpine"},
+		{"dart", "", "3.3", "dart:3.3"},
+		{"flutter", "", "3.19.6", "ghcr.io/cirruslabs/flutter:3.19.6"},
+		{"cpp", "", "13", "gcc:13"},
+		{"clojure", "lein", "21", "clojure:temurin-21-lein"},
+		{"clojure", "clojure", "21", "clojure:temurin-21-tools-deps"},
+		{"haskell", "stack", "9.6", "haskell:9.6"},
+		{"deno", "deno", "1.41.3", "denoland/deno:1.41.3"},
+		{"bun", "bun", "1.1", "oven/bun:1.1"},
+		{"zig", "zig", "0.12.0", "euantorano/zig:0.12.0"},
+		{"nim", "nimble", "2.0.8", "nimlang/nim:2.0.8-alpine"},
+		{"crystal", "shards", "1.11.2", "crystallang/crystal:1.11.2-alpine"},
+		{"r", "renv", "4.3.2", "rocker/r-ver:4.3.2"},
+		{"julia", "Pkg", "1.10.2", "julia:1.10.2"},
+		{"perl", "cpanm", "5.38", "perl:5.38-slim"},
+		{"lua", "luarocks", "5.4", "nickblah/lua:5.4-alpine"},
+		{"bash", "", "5.2", "bash:5.2"},
+		{"sh", "", "3.20", "alpine:3.20"},
+		{"unknown", "", "1.0", "unknown:1.0-alpine"}, // fallback
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.language, func(t *testing.T) {
-			result := detector.generateImageTag(tt.language, tt.version)
+		t.Run(tt.language+"/"+tt.buildTool, func(t *testing.T) {
+			result, err := detector.generateImageTag(tt.language, tt.buildTool, tt.version)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
 			if result != tt.expected {
 				t.Errorf("Expected %q, got %q", tt.expected, result)
 			}
@@ -221,6 +371,41 @@ func TestDetect(t *testing.T) {
 			expected:    "go",
 			shouldError: false,
 		},
+		{
+			name: "Kotlin Gradle project",
+			files: map[string]string{
+				"build.gradle.kts": "plugins {\n    kotlin(\"jvm\") version \"2.0.0\"\n}",
+			},
+			expected:    "kotlin",
+			shouldError: false,
+		},
+		{
+			name: "Java Gradle Kotlin DSL project",
+			files: map[string]string{
+				"build.gradle.kts": "plugins {\n    java\n}",
+			},
+			expected:    "java",
+			shouldError: false,
+		},
+		{
+			name: "Python project with a CMake extension",
+			files: map[string]string{
+				"pyproject.toml":   "[project]\nname = \"ext\"",
+				"requirements.txt": "numpy==1.26.4",
+				"CMakeLists.txt":   "project(ext C)",
+			},
+			expected:    "python",
+			shouldError: false,
+		},
+		{
+			name: "Rust project with a CMake build",
+			files: map[string]string{
+				"Cargo.toml":     "[package]\nname = \"sys\"",
+				"CMakeLists.txt": "project(sys C)",
+			},
+			expected:    "rust",
+			shouldError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -278,3 +463,610 @@ func TestDetectNonExistentPath(t *testing.T) {
 		t.Error("Expected error for non-existent path")
 	}
 }
+
+func TestDetectRuntimeImageTag(t *testing.T) {
+	detector := NewDetector()
+
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Scala version must not leak into the JDK image tag
+	files := map[string]string{
+		"build.sbt":     `scalaVersion := "3.3.1"`,
+		".java-version": "21",
+	}
+	for filename, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	result, err := detector.Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lang := result.Language
+	if lang.Name != "scala" {
+		t.Fatalf("Expected language %q, got %q", "scala", lang.Name)
+	}
+	if lang.LanguageVersion != "3.3" {
+		t.Errorf("Expected language version %q, got %q", "3.3", lang.LanguageVersion)
+	}
+	if lang.Runtime.Name != "jvm" || lang.Runtime.Version != "21" {
+		t.Errorf("Expected runtime jvm 21, got %s %s", lang.Runtime.Name, lang.Runtime.Version)
+	}
+	if lang.CIImageTag != "eclipse-temurin:21-jdk-alpine" {
+		t.Errorf("Expected image %q, got %q", "eclipse-temurin:21-jdk-alpine", lang.CIImageTag)
+	}
+}
+
+func TestDetectElixirImageTag(t *testing.T) {
+	detector := NewDetector()
+
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"mix.exs":        `elixir: "~> 1.15"`,
+		".tool-versions": "erlang 26.1.2\n",
+	}
+	for filename, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	result, err := detector.Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lang := result.Language
+	if lang.CIImageTag != "elixir:1.15-alpine" {
+		t.Errorf("Expected image %q, got %q", "elixir:1.15-alpine", lang.CIImageTag)
+	}
+	if lang.Runtime.Name != "otp" || lang.Runtime.Version != "26.1.2" {
+		t.Errorf("Expected runtime otp 26.1.2, got %s %s", lang.Runtime.Name, lang.Runtime.Version)
+	}
+	if lang.BuildTool != "mix" {
+		t.Errorf("Expected build tool %q, got %q", "mix", lang.BuildTool)
+	}
+}
+
+func TestDetectIaC(t *testing.T) {
+	detector := NewDetector()
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		language string
+		expected []string // name:version:image
+	}{
+		{
+			name: "Terraform-only repository",
+			files: map[string]string{
+				"main.tf":            "terraform {\n  required_version = \">= 1.5.0\"\n}\n",
+				".terraform-version": "1.7.5",
+			},
+			language: "",
+			expected: []string{"terraform:1.7.5:hashicorp/terraform:1.7.5"},
+		},
+		{
+			name: "OpenTofu supersedes Terraform",
+			files: map[string]string{
+				"main.tf":           "",
+				".opentofu-version": "1.8.1",
+			},
+			language: "",
+			expected: []string{"opentofu:1.8.1:ghcr.io/opentofu/opentofu:1.8.1"},
+		},
+		{
+			name: "Pulumi program with Helm chart",
+			files: map[string]string{
+				"Pulumi.yaml":  "name: infra\nruntime: nodejs\n",
+				"package.json": `{"dependencies": {"@pulumi/pulumi": "3.113.0"}}`,
+				"Chart.yaml":   "apiVersion: v2\nname: app\n",
+			},
+			language: "node",
+			expected: []string{"helm:3.15.4:alpine/helm:3.15.4", "pulumi:3.113.0:pulumi/pulumi:3.113.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			for filename, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Language.Name != tt.language {
+				t.Errorf("Expected language %q, got %q", tt.language, result.Language.Name)
+			}
+			if len(result.IaC) != len(tt.expected) {
+				t.Fatalf("Expected %d IaC tools, got %+v", len(tt.expected), result.IaC)
+			}
+			for i, tool := range result.IaC {
+				got := tool.Name + ":" + tool.Version + ":" + tool.CIImageTag
+				if got != tt.expected[i] {
+					t.Errorf("Expected %q, got %q", tt.expected[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectShellRepository(t *testing.T) {
+	detector := NewDetector()
+
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// POSIX-only scripts can run on a plain Alpine image
+	files := map[string]string{
+		"install.sh": "#!/bin/sh\necho install\n",
+		"check.sh":   "#!/usr/bin/env sh\necho check\n",
+	}
+	for filename, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	result, err := detector.Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Language.Name != "sh" {
+		t.Errorf("Expected language %q, got %q", "sh", result.Language.Name)
+	}
+	if result.Language.CIImageTag != "alpine:3.20" {
+		t.Errorf("Expected image %q, got %q", "alpine:3.20", result.Language.CIImageTag)
+	}
+}
+
+func TestDetectClangProject(t *testing.T) {
+	tests := []struct {
+		name            string
+		files           map[string]string
+		expectedRuntime string
+		expectedImage   string
+	}{
+		{"CMake selects Clang 17", map[string]string{"CMakeLists.txt": "set(CMAKE_CXX_COMPILER clang++-17)\nproject(app CXX)\n"}, "clang 17", "silkeh/clang:17"},
+		{"Makefile selects Clang", map[string]string{"Makefile": "CC = clang\n", "main.c": "int main(void) { return 0; }\n"}, "clang 19", "silkeh/clang:19"},
+		{"GCC by default", map[string]string{"CMakeLists.txt": "project(app C)\n"}, "gcc 13", "gcc:13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			for filename, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			}
+
+			result, err := NewDetector().Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			runtime := result.Language.Runtime
+			if got := runtime.Name + " " + runtime.Version; got != tt.expectedRuntime {
+				t.Errorf("Expected runtime %q, got %q", tt.expectedRuntime, got)
+			}
+			if result.Language.CIImageTag != tt.expectedImage {
+				t.Errorf("Expected image %q, got %q", tt.expectedImage, result.Language.CIImageTag)
+			}
+		})
+	}
+}
+
+func TestDetectMakefileProject(t *testing.T) {
+	detector := NewDetector()
+
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"Makefile": "all:\n\tcc -o x lib/x.c\n",
+		"lib/x.c":  "int main(void) { return 0; }\n",
+	}
+	for filename, content := range files {
+		file := filepath.Join(tmpDir, filename)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Failed to create test dir: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	result, err := detector.Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Language.Name != "c" {
+		t.Errorf("Expected language %q, got %q", "c", result.Language.Name)
+	}
+	if result.Language.BuildTool != "make" {
+		t.Errorf("Expected build tool %q, got %q", "make", result.Language.BuildTool)
+	}
+}
+
+func TestDetectNativeImageVariant(t *testing.T) {
+	detector := NewDetector()
+
+	tests := []struct {
+		name            string
+		files           map[string]string
+		expectedImage   string
+		expectedVariant string
+		expectedReason  string
+	}{
+		{
+			name: "Node with native addons",
+			files: map[string]string{
+				"package.json":      `{"dependencies": {"sharp": "^0.33.0"}}`,
+				"package-lock.json": `{"packages": {"node_modules/sharp": {"version": "0.33.2"}, "node_modules/bcrypt": {"version": "5.1.1"}}}`,
+				".nvmrc":            "20",
+			},
+			expectedImage:   "node:20-bookworm-slim",
+			expectedVariant: "glibc",
+			expectedReason:  "native dependencies need glibc: bcrypt, sharp",
+		},
+		{
+			name: "Node without native addons",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"express": "^4.19.0"}}`,
+				".nvmrc":       "20",
+			},
+			expectedImage: "node:20-alpine",
+		},
+		{
+			name: "Ruby with nokogiri",
+			files: map[string]string{
+				"Gemfile":       "gem 'rails'\n",
+				"Gemfile.lock":  "GEM\n  specs:\n    nokogiri (1.16.3-x86_64-linux)\n    rails (7.1.3)\n",
+				".ruby-version": "3.3",
+			},
+			expectedImage:   "ruby:3.3-slim",
+			expectedVariant: "glibc",
+			expectedReason:  "native dependencies need glibc: nokogiri",
+		},
+		{
+			name: "Python image is already glibc",
+			files: map[string]string{
+				"requirements.txt": "numpy==1.26.4\n",
+				".python-version":  "3.12",
+			},
+			expectedImage:   "python:3.12-slim",
+			expectedVariant: "glibc",
+			expectedReason:  "native dependencies need glibc: numpy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			for filename, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			lang := result.Language
+			if lang.CIImageTag != tt.expectedImage {
+				t.Errorf("Expected image %q, got %q", tt.expectedImage, lang.CIImageTag)
+			}
+			if lang.ImageVariant != tt.expectedVariant {
+				t.Errorf("Expected variant %q, got %q", tt.expectedVariant, lang.ImageVariant)
+			}
+			if lang.ImageReason != tt.expectedReason {
+				t.Errorf("Expected reason %q, got %q", tt.expectedReason, lang.ImageReason)
+			}
+		})
+	}
+}
+
+func TestDefaultVersionsInCatalog(t *testing.T) {
+	detector := NewDetector()
+
+	for language, cfg := range detector.config {
+		version := cfg.DefaultVersion
+		if cfg.ImageFromLanguageVersion {
+			version = cfg.DefaultLanguageVersion
+		}
+		if _, err := detector.generateImageTag(language, "", version); err != nil {
+			t.Errorf("%s: %v", language, err)
+		}
+		for compiler, compilerCfg := range cfg.Compilers {
+			if _, err := detector.catalog.Resolve(compilerCfg.ImageTemplate, compilerCfg.DefaultVersion); err != nil {
+				t.Errorf("%s %s: %v", language, compiler, err)
+			}
+		}
+		templates := []string{cfg.RuntimeImageTemplate}
+		for _, template := range cfg.AppTypeRuntimeImageTemplates {
+			templates = append(templates, template)
+		}
+		for _, template := range templates {
+			if _, err := detector.catalog.Resolve(template, version); template != "" && err != nil {
+				t.Errorf("%s runtime image: %v", language, err)
+			}
+		}
+	}
+	for name, cfg := range detector.iacConfig {
+		if _, err := detector.catalog.Resolve(cfg.ImageTemplate, cfg.DefaultVersion); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestDetectUnknownImageTag(t *testing.T) {
+	detector := NewDetector()
+
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/app\n\ngo 1.19\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	result, err := detector.Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Language.CIImageTag != "golang:1.19-alpine" {
+		t.Errorf("Expected image %q, got %q", "golang:1.19-alpine", result.Language.CIImageTag)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "golang has no tag for version 1.19") {
+		t.Errorf("Expected a warning for golang 1.19, got %v", result.Warnings)
+	}
+}
+
+func TestDetectImageWarningsOnce(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for filename, content := range map[string]string{"pom.xml": "<project></project>", ".java-version": "9\n"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	result, err := NewDetector().Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	count := 0
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning, "eclipse-temurin has no tag for version 9") {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected one warning for eclipse-temurin 9, got %v", result.Warnings)
+	}
+}
+
+func TestDetectRustStableChannel(t *testing.T) {
+	detector := NewDetector()
+
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for filename, content := range map[string]string{"Cargo.toml": "[package]\nname = \"app\"\n", "rust-toolchain.toml": "[toolchain]\nchannel = \"stable\"\n"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	result, err := detector.Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The newest Rust in the catalog is the newest release in the lifecycle data
+	newest := ""
+	for _, cycle := range detector.lifecycle.Runtimes["rust"] {
+		if newest == "" || version.Compare(cycle.Cycle, newest) > 0 {
+			newest = cycle.Cycle
+		}
+	}
+	if expected := "rust:" + newest + "-alpine"; result.Language.CIImageTag != expected {
+		t.Errorf("Expected image %q, got %q", expected, result.Language.CIImageTag)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", result.Warnings)
+	}
+}
+
+func TestDetectRuntimeEOL(t *testing.T) {
+	detector := NewDetector()
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected *bool
+		warning  string
+	}{
+		{"End of life", map[string]string{"requirements.txt": "", ".python-version": "3.7.17"}, boolPtr(true), "python 3.7 reached end of life"},
+		{"Supported", map[string]string{"requirements.txt": "", ".python-version": "3.14.0"}, boolPtr(false), ""},
+		{"Default version is not checked", map[string]string{"requirements.txt": ""}, nil, ""},
+		{"Superseded Rust release", map[string]string{"Cargo.toml": "[package]\nname = \"app\"\n", "rust-toolchain.toml": "[toolchain]\nchannel = \"1.70.0\"\n"}, boolPtr(true), "rust 1.70 reached end of life"},
+		{"Runtime without lifecycle data", map[string]string{"Package.swift": "// swift-tools-version:5.9\n"}, nil, "no lifecycle data for swift 5.9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			for filename, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			eol := result.Language.Runtime.EOL
+			if (eol == nil) != (tt.expected == nil) || (eol != nil && *eol != *tt.expected) {
+				t.Errorf("Expected eol %v, got %v", tt.expected, eol)
+			}
+			if eol != nil && result.Language.Runtime.EOLDate == "" {
+				t.Error("Expected an eol_date")
+			}
+			if tt.warning != "" && !slices.ContainsFunc(result.Warnings, func(w string) bool { return strings.Contains(w, tt.warning) }) {
+				t.Errorf("Expected a warning %q, got %v", tt.warning, result.Warnings)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestDetectDeployImageTag(t *testing.T) {
+	detector := NewDetector()
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+		appType  string
+	}{
+		{
+			name:     "JRE for Maven project",
+			files:    map[string]string{"pom.xml": "<project><properties><java.version>21</java.version></properties></project>"},
+			expected: "eclipse-temurin:21-jre-alpine",
+		},
+		{
+			name:     "ASP.NET Core runtime for web project",
+			files:    map[string]string{"Api.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`},
+			expected: "mcr.microsoft.com/dotnet/aspnet:8.0",
+			appType:  "web",
+		},
+		{
+			name:     ".NET runtime for worker project",
+			files:    map[string]string{"Jobs.csproj": `<Project Sdk="Microsoft.NET.Sdk.Worker"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`},
+			expected: "mcr.microsoft.com/dotnet/runtime:8.0",
+			appType:  "worker",
+		},
+		{
+			name:     "Distroless for Go binary",
+			files:    map[string]string{"go.mod": "module example.com/tool\n\ngo 1.22\n\nrequire github.com/spf13/cobra v1.8.0\n"},
+			expected: "gcr.io/distroless/static",
+			appType:  "cli",
+		},
+		{
+			name:     "CI image runs Python",
+			files:    map[string]string{"requirements.txt": "", ".python-version": "3.12"},
+			expected: "python:3.12-slim",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			for filename, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			}
+
+			result, err := detector.Detect(tmpDir)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.Language.RuntimeImageTag != tt.expected {
+				t.Errorf("Expected runtime image %q, got %q", tt.expected, result.Language.RuntimeImageTag)
+			}
+			if result.Language.AppType != tt.appType {
+				t.Errorf("Expected app type %q, got %q", tt.appType, result.Language.AppType)
+			}
+		})
+	}
+}
+
+func TestDetectCommands(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	pkg := `{"scripts": {"build": "tsc", "test": "vitest run"}, "devDependencies": {"vitest": "^1.0.0"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "package.json"), []byte(pkg), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	result, err := NewDetector().Detect(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Commands == nil || result.Commands.Build != "npm run build" || result.Commands.Test != "npm test" {
+		t.Fatalf("Expected npm build and test commands, got %+v", result.Commands)
+	}
+	if result.BuildCommand != result.Commands.Build || result.TestCommand != result.Commands.Test {
+		t.Errorf("Expected build_command and test_command to match commands, got %q and %q", result.BuildCommand, result.TestCommand)
+	}
+}
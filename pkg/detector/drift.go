@@ -0,0 +1,142 @@
+package detector
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+	"github.com/stack-radar/stackradar/pkg/parsers"
+	"github.com/stack-radar/stackradar/pkg/version"
+)
+
+// Drift lists the runtimes whose pinned versions disagree in the repository at
+// root, or in every project below it when recursive is set. The versions of a
+// runtime are those pinned by version files, Dockerfiles and CI configuration,
+// together with the version detected for the runtime of the project. A less
+// precise version agrees with the versions it is a prefix of, so 20 agrees with
+// 20.11.1 but not with 18. When the manifest declares a range rather than an
+// exact version, such as ">=18" or "^3.11", the pins must satisfy the range
+// instead.
+//
+// CI configuration is read at root and its pins are matched to the project
+// their job runs in. Pins of jobs that name no directory apply to every project.
+func (d *Detector) Drift(root string, recursive bool) ([]models.Drift, error) {
+	all, err := d.FindProjects(root)
+	if err != nil {
+		return nil, err
+	}
+	projects := []string{"."}
+	if recursive {
+		projects = all
+	}
+	ciPins := parsers.DetectCIVersionPins(root)
+
+	var drifts []models.Drift
+	for _, project := range projects {
+		path := filepath.Join(root, project)
+		stack, err := d.Detect(path)
+		if err != nil {
+			if recursive {
+				continue
+			}
+			return nil, err
+		}
+
+		lang := stack.Language
+		detected, ranges := "", []version.Constraint(nil)
+		if lang.Name != "" {
+			detected = parsers.DetectRuntimeRange(path, lang.Name)
+			if detected != "" {
+				ranges, err = version.ParseRange(detected)
+			}
+			if detected == "" || err != nil || exactRange(ranges) {
+				detected, ranges = parsers.DetectRuntimeVersion(path, lang.Name, lang.BuildTool), nil
+			}
+		}
+
+		var runtimes []string
+		pins := make(map[string][]models.VersionPin)
+		projectPins := parsers.DetectVersionPins(path)
+		for _, pin := range ciPins {
+			if pin.Dir == "" || owningProject(all, pin.Dir) == filepath.ToSlash(project) {
+				pin.File = filepath.ToSlash(filepath.Join(relativeRoot(project), pin.File))
+				projectPins = append(projectPins, pin.VersionPin)
+			}
+		}
+		for _, pin := range projectPins {
+			if _, seen := pins[pin.Runtime]; !seen {
+				runtimes = append(runtimes, pin.Runtime)
+			}
+			pins[pin.Runtime] = append(pins[pin.Runtime], pin)
+		}
+
+		for _, runtime := range runtimes {
+			versions := make([]string, 0, len(pins[runtime])+1)
+			for _, pin := range pins[runtime] {
+				versions = append(versions, pin.Version)
+			}
+			drift := models.Drift{Project: filepath.ToSlash(project), Runtime: runtime, Pins: pins[runtime]}
+			agree := versionsAgree(versions)
+			if runtime == lang.Runtime.Name && ranges != nil {
+				drift.Detected = detected
+				for _, v := range versions {
+					agree = agree && slices.ContainsFunc(ranges, func(c version.Constraint) bool { return c.Matches(v) })
+				}
+			} else if runtime == lang.Runtime.Name && driftVersion.MatchString(detected) {
+				drift.Detected = driftVersion.FindString(detected)
+				agree = versionsAgree(append(versions, drift.Detected))
+			}
+			if !agree {
+				drifts = append(drifts, drift)
+			}
+		}
+	}
+	return drifts, nil
+}
+
+// owningProject returns the project a directory belongs to: the deepest project
+// containing it, or the repository root
+func owningProject(projects []string, dir string) string {
+	owner := "."
+	for _, project := range projects {
+		project = filepath.ToSlash(project)
+		if (dir == project || strings.HasPrefix(dir, project+"/")) && len(project) > len(owner) {
+			owner = project
+		}
+	}
+	return owner
+}
+
+// relativeRoot returns the path from a project back to the repository root
+func relativeRoot(project string) string {
+	rel, err := filepath.Rel(project, ".")
+	if err != nil {
+		return "."
+	}
+	return rel
+}
+
+// driftVersion matches the dotted numeric part of a detected version, e.g. 20.11.1
+// in v20.11.1
+var driftVersion = regexp.MustCompile(`\d+(?:\.\d+)*`)
+
+// exactRange reports whether a range names a single version rather than a
+// range of them, e.g. 20.11.1 or ==3.12.*
+func exactRange(ranges []version.Constraint) bool {
+	return len(ranges) == 1 && len(ranges[0]) == 1 && ranges[0][0].Op == "="
+}
+
+// versionsAgree reports whether every pair of versions agrees on the components
+// both specify
+func versionsAgree(versions []string) bool {
+	for i, a := range versions {
+		for _, b := range versions[i+1:] {
+			if !(version.Comparison{Op: "=", Version: a}).Matches(b) && !(version.Comparison{Op: "=", Version: b}).Matches(a) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDrift(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected map[string]string // Drifting runtime to detected version
+	}{
+		{
+			name: "Dockerfile behind .nvmrc and CI",
+			files: map[string]string{
+				"package.json":             `{"name": "app"}`,
+				".nvmrc":                   "20.11.1\n",
+				"Dockerfile":               "FROM node:18-alpine\n",
+				".github/workflows/ci.yml": "jobs:\n  test:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: 20\n",
+			},
+			expected: map[string]string{"node": "20.11.1"},
+		},
+		{
+			name: "CI image behind the manifest",
+			files: map[string]string{
+				"go.mod":         "module example.com/app\n\ngo 1.23\n",
+				".gitlab-ci.yml": "test:\n  image: golang:1.22-alpine\n",
+			},
+			expected: map[string]string{"go": "1.23"},
+		},
+		{
+			name: "Less precise versions agree",
+			files: map[string]string{
+				"go.mod":     "module example.com/app\n\ngo 1.22.5\n",
+				"Dockerfile": "FROM golang:1.22 AS build\nFROM gcr.io/distroless/static\n",
+			},
+			expected: map[string]string{},
+		},
+		{
+			name: "Pins of another runtime disagreeing with each other",
+			files: map[string]string{
+				"go.mod":          "module example.com/app\n\ngo 1.22\n",
+				".python-version": "3.12\n",
+				"Dockerfile":      "FROM python:3.11-slim\n",
+			},
+			expected: map[string]string{"python": ""},
+		},
+		{
+			name: "CI within the engines range",
+			files: map[string]string{
+				"package.json":             `{"name": "app", "engines": {"node": ">=18"}}`,
+				".github/workflows/ci.yml": "jobs:\n  test:\n    steps:\n      - uses: actions/setup-node@v4\n        with:\n          node-version: 22\n",
+			},
+			expected: map[string]string{},
+		},
+		{
+			name: "Version file within the Poetry range",
+			files: map[string]string{
+				"pyproject.toml":  "[tool.poetry]\nname = \"app\"\n\n[tool.poetry.dependencies]\npython = \"^3.11\"\n",
+				".python-version": "3.12\n",
+			},
+			expected: map[string]string{},
+		},
+		{
+			name: "Dockerfile outside the requires-python range",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"app\"\nrequires-python = \">=3.11,<3.13\"\n",
+				"Dockerfile":     "FROM python:3.13-slim\n",
+			},
+			expected: map[string]string{"python": ">=3.11,<3.13"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			for filename, content := range tt.files {
+				file := filepath.Join(tmpDir, filename)
+				if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+					t.Fatalf("Failed to create test dir: %v", err)
+				}
+				if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			}
+
+			drifts, err := NewDetector().Drift(tmpDir, false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(drifts) != len(tt.expected) {
+				t.Fatalf("Expected %d drifting runtime(s), got %+v", len(tt.expected), drifts)
+			}
+			for _, drift := range drifts {
+				detected, ok := tt.expected[drift.Runtime]
+				if !ok {
+					t.Errorf("Unexpected drift of %s: %+v", drift.Runtime, drift)
+				} else if drift.Detected != detected {
+					t.Errorf("Expected %s to be detected at %q, got %q", drift.Runtime, detected, drift.Detected)
+				}
+			}
+		})
+	}
+}
+
+func TestDriftRecursive(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"web/package.json": `{"name": "web"}`,
+		"web/.nvmrc":       "18\n",
+		"api/go.mod":       "module example.com/api\n\ngo 1.22\n",
+		".github/workflows/ci.yml": `jobs:
+  web:
+    defaults:
+      run:
+        working-directory: web
+    steps:
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 22
+  api:
+    steps:
+      - uses: actions/setup-go@v5
+        with:
+          go-version: 1.22
+      - run: go test ./...
+        working-directory: api
+`,
+	}
+	for filename, content := range files {
+		file := filepath.Join(tmpDir, filename)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Failed to create test dir: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	drifts, err := NewDetector().Drift(tmpDir, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(drifts) != 1 {
+		t.Fatalf("Expected 1 drifting runtime, got %+v", drifts)
+	}
+	if drifts[0].Project != "web" || drifts[0].Runtime != "node" {
+		t.Errorf("Expected node to drift in web, got %+v", drifts[0])
+	}
+	if pin := drifts[0].Pins[len(drifts[0].Pins)-1]; pin.Version != "22" || pin.File != "../.github/workflows/ci.yml" {
+		t.Errorf("Expected the root workflow pin, got %+v", pin)
+	}
+}
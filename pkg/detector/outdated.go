@@ -0,0 +1,143 @@
+package detector
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+	"github.com/stack-radar/stackradar/pkg/parsers"
+)
+
+// projectSearchDepth limits how deep FindProjects looks for projects in a monorepo
+const projectSearchDepth = 4
+
+// sharedVersionFiles may pin the runtime version of any language
+var sharedVersionFiles = []string{
+	".tool-versions",
+	"Dockerfile",
+	"*.Dockerfile",
+	".github/workflows/*.yml",
+	".github/workflows/*.yaml",
+	".gitlab-ci.yml",
+}
+
+// FindProjects lists the directories of a repository, relative to its root, that
+// contain a project manifest of a configured language. Hidden and dependency
+// directories are skipped.
+func (d *Detector) FindProjects(root string) ([]string, error) {
+	var projects []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if rel != "." {
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || parsers.SkippedDirs[name] {
+				return filepath.SkipDir
+			}
+			if strings.Count(rel, string(filepath.Separator)) >= projectSearchDepth {
+				return filepath.SkipDir
+			}
+		}
+		if d.hasManifest(path) {
+			projects = append(projects, rel)
+		}
+		return nil
+	})
+	return projects, err
+}
+
+// hasManifest checks whether a directory holds a file indicator of any configured language
+func (d *Detector) hasManifest(path string) bool {
+	for _, cfg := range d.config {
+		for _, pattern := range cfg.FileIndicators {
+			if fileExists(path, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Outdated lists runtime upgrade candidates for the repository at root, or for every
+// project below it when recursive is set. Only runtimes whose version the project
+// declares and that lifecycle data covers are reported.
+func (d *Detector) Outdated(root string, recursive bool) ([]models.Upgrade, error) {
+	projects := []string{"."}
+	if recursive {
+		var err error
+		if projects, err = d.FindProjects(root); err != nil {
+			return nil, err
+		}
+	}
+
+	var upgrades []models.Upgrade
+	for _, project := range projects {
+		path := filepath.Join(root, project)
+		stack, err := d.Detect(path)
+		if err != nil {
+			if recursive {
+				continue
+			}
+			return nil, err
+		}
+
+		runtime := stack.Language.Runtime
+		if runtime.EOL == nil || (!*runtime.EOL && runtime.Latest == "" && runtime.LatestLTS == "") {
+			continue
+		}
+		upgrades = append(upgrades, models.Upgrade{
+			Project:   filepath.ToSlash(project),
+			Language:  stack.Language.Name,
+			Runtime:   runtime.Name,
+			Version:   runtime.Version,
+			Latest:    runtime.Latest,
+			LatestLTS: runtime.LatestLTS,
+			EOL:       *runtime.EOL,
+			Files:     d.versionFiles(path, stack.Language.Name, runtime),
+		})
+	}
+	return upgrades, nil
+}
+
+// versionFiles lists the files of a project that mention its runtime version,
+// either in full (3.9.18) or as its release cycle (3.9)
+func (d *Detector) versionFiles(path, language string, runtime models.Runtime) []string {
+	versions := []string{runtime.Version}
+	if cycle, ok := d.lifecycle.Lookup(runtime.Name, runtime.Version); ok && cycle.Cycle != runtime.Version {
+		versions = append(versions, cycle.Cycle)
+	}
+	var patterns []string
+	for _, version := range versions {
+		patterns = append(patterns, regexp.QuoteMeta(version))
+	}
+	mention := regexp.MustCompile(`(^|[^\d.])(` + strings.Join(patterns, "|") + `)($|[^\d])`)
+
+	var files []string
+	seen := make(map[string]bool)
+	for _, pattern := range slices.Concat(d.config[language].VersionFiles, sharedVersionFiles) {
+		matches, _ := filepath.Glob(filepath.Join(path, pattern))
+		for _, match := range matches {
+			rel, err := filepath.Rel(path, match)
+			if err != nil || seen[rel] {
+				continue
+			}
+			if content, err := os.ReadFile(match); err == nil && mention.Match(content) {
+				seen[rel] = true
+				files = append(files, filepath.ToSlash(rel))
+			}
+		}
+	}
+	return files
+}
@@ -0,0 +1,92 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindProjects(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"go.mod":                                   "module example.com/app\n",
+		"services/api/pyproject.toml":              "[project]\nname = \"api\"\n",
+		"services/web/package.json":                "{}",
+		"services/web/node_modules/x/package.json": "{}",
+		".github/actions/setup/package.json":       "{}",
+		"docs/README.md":                           "# Docs\n",
+	}
+	for filename, content := range files {
+		file := filepath.Join(tmpDir, filename)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Failed to create test dir: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	projects, err := NewDetector().FindProjects(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{".", filepath.Join("services", "api"), filepath.Join("services", "web")}
+	if !reflect.DeepEqual(projects, expected) {
+		t.Errorf("Expected %v, got %v", expected, projects)
+	}
+}
+
+func TestOutdated(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"web/package.json":        `{"engines": {"node": ">=18"}}`,
+		"web/.nvmrc":              "18\n",
+		"web/Dockerfile":          "FROM node:18-alpine\n",
+		"api/requirements.txt":    "flask==3.0.0\n",
+		"api/.python-version":     "3.9.18\n",
+		"api/Dockerfile":          "FROM python:3.9-slim\n",
+		"worker/requirements.txt": "", // no declared version
+	}
+	for filename, content := range files {
+		file := filepath.Join(tmpDir, filename)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Failed to create test dir: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	upgrades, err := NewDetector().Outdated(tmpDir, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(upgrades) != 2 {
+		t.Fatalf("Expected 2 upgrade candidates, got %+v", upgrades)
+	}
+
+	api, web := upgrades[0], upgrades[1]
+	if api.Project != "api" || api.Runtime != "python" || !api.EOL || api.Latest == "" {
+		t.Errorf("Unexpected python upgrade %+v", api)
+	}
+	if !reflect.DeepEqual(api.Files, []string{".python-version", "Dockerfile"}) {
+		t.Errorf("Expected python version files, got %v", api.Files)
+	}
+	if web.Project != "web" || web.Runtime != "node" || !web.EOL || web.LatestLTS == "" {
+		t.Errorf("Unexpected node upgrade %+v", web)
+	}
+	if !reflect.DeepEqual(web.Files, []string{".nvmrc", "package.json", "Dockerfile"}) {
+		t.Errorf("Expected node version files, got %v", web.Files)
+	}
+}
@@ -0,0 +1,242 @@
+package detector
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+	"github.com/stack-radar/stackradar/pkg/parsers"
+	"github.com/stack-radar/stackradar/pkg/version"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy describes the languages, runtime versions, build tools and images a
+// repository is allowed to use
+type Policy struct {
+	Languages       AllowList         `yaml:"languages"`
+	Runtimes        map[string]string `yaml:"runtimes"` // Language or runtime name to constraint, e.g. jvm: ">=17" or node: lts
+	BuildTools      AllowList         `yaml:"build_tools"`
+	PackageManagers AllowList         `yaml:"package_managers"`
+	Images          struct {
+		Registries []string `yaml:"registries"` // Allowed registry prefixes, e.g. registry.corp
+	} `yaml:"images"`
+}
+
+// AllowList allows or denies names. An empty Allow list allows every name not denied.
+type AllowList struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
+// permits reports whether an allow list admits a name
+func (a AllowList) permits(name string) bool {
+	if slices.Contains(a.Deny, name) {
+		return false
+	}
+	return len(a.Allow) == 0 || slices.Contains(a.Allow, name)
+}
+
+// Violation is a policy rule a repository breaks, with the files that show it
+type Violation struct {
+	Rule    string   `json:"rule" yaml:"rule"`
+	Message string   `json:"message" yaml:"message"`
+	Files   []string `json:"files,omitempty" yaml:"files,omitempty"`
+}
+
+// buildToolFiles are the files that select a build tool or package manager
+var buildToolFiles = map[string][]string{
+	"pip":      {"requirements.txt", "setup.py", "pyproject.toml"},
+	"pipenv":   {"Pipfile", "Pipfile.lock"},
+	"poetry":   {"pyproject.toml", "poetry.lock"},
+	"pdm":      {"pyproject.toml", "pdm.lock"},
+	"hatch":    {"pyproject.toml"},
+	"uv":       {"pyproject.toml", "uv.lock"},
+	"npm":      {"package.json", "package-lock.json"},
+	"yarn":     {"package.json", "yarn.lock"},
+	"pnpm":     {"package.json", "pnpm-lock.yaml"},
+	"maven":    {"pom.xml", "mvnw"},
+	"gradle":   {"build.gradle", "build.gradle.kts", "gradlew"},
+	"sbt":      {"build.sbt"},
+	"bundle":   {"Gemfile", "Gemfile.lock"},
+	"composer": {"composer.json", "composer.lock"},
+	"cmake":    {"CMakeLists.txt"},
+	"make":     {"Makefile"},
+	"conan":    {"conanfile.txt", "conanfile.py"},
+	"vcpkg":    {"vcpkg.json"},
+	"lein":     {"project.clj"},
+}
+
+// LoadPolicy reads a policy from a YAML file
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	// Unknown keys are rejected, as a misspelled rule would otherwise turn it off
+	var p Policy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+	for name, constraint := range p.Runtimes {
+		if strings.TrimSpace(constraint) == "lts" {
+			continue
+		}
+		if _, err := version.ParseConstraint(constraint); err != nil {
+			return nil, fmt.Errorf("policy %s: runtimes.%s: %w", path, name, err)
+		}
+	}
+	return &p, nil
+}
+
+// Check detects the tech stack of a repository and lists the policy rules it violates
+func (d *Detector) Check(path string, p *Policy) ([]Violation, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	stack, err := d.Detect(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	lang := stack.Language
+	if lang.Name != "" && !p.Languages.permits(lang.Name) {
+		violations = append(violations, Violation{
+			Rule:    "languages",
+			Message: fmt.Sprintf("language %s is not allowed", lang.Name),
+			Files:   d.languageFiles(absPath, lang.Name),
+		})
+	}
+
+	if constraint, name, ok := p.runtimeConstraint(lang); ok {
+		// Without a pinned version the runtime version is our default, which says
+		// nothing about the repository
+		message := fmt.Sprintf("%s version is not pinned", lang.Runtime.Name)
+		files := d.languageFiles(absPath, lang.Name)
+		if parsers.DetectRuntimeVersion(absPath, lang.Name, lang.BuildTool) != "" {
+			message = d.checkRuntime(lang.Runtime, constraint)
+			files = d.versionFiles(absPath, lang.Name, lang.Runtime)
+		}
+		if message != "" {
+			violations = append(violations, Violation{
+				Rule:    "runtimes." + name,
+				Message: message,
+				Files:   files,
+			})
+		}
+	}
+
+	if lang.BuildTool != "" && !p.BuildTools.permits(lang.BuildTool) {
+		violations = append(violations, Violation{
+			Rule:    "build_tools",
+			Message: fmt.Sprintf("build tool %s is not allowed", lang.BuildTool),
+			Files:   d.toolFiles(absPath, lang.Name, lang.BuildTool),
+		})
+	}
+	if lang.PackageManager != "" && !p.PackageManagers.permits(lang.PackageManager) {
+		violations = append(violations, Violation{
+			Rule:    "package_managers",
+			Message: fmt.Sprintf("package manager %s is not allowed", lang.PackageManager),
+			Files:   d.toolFiles(absPath, lang.Name, lang.PackageManager),
+		})
+	}
+
+	if len(p.Images.Registries) > 0 {
+		for _, ref := range parsers.DetectImageReferences(absPath) {
+			if !fromRegistry(ref.Image, p.Images.Registries) {
+				violations = append(violations, Violation{
+					Rule:    "images.registries",
+					Message: fmt.Sprintf("image %s is not from an allowed registry", ref.Image),
+					Files:   []string{ref.File},
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// runtimeConstraint finds the constraint for a language, by language name first and runtime name second
+func (p *Policy) runtimeConstraint(lang models.Language) (constraint, name string, ok bool) {
+	for _, name := range []string{lang.Name, lang.Runtime.Name} {
+		if constraint, ok := p.Runtimes[name]; ok {
+			return constraint, name, true
+		}
+	}
+	return "", "", false
+}
+
+// checkRuntime describes how a runtime violates a constraint, or returns "" when it satisfies it
+func (d *Detector) checkRuntime(runtime models.Runtime, constraint string) string {
+	if strings.TrimSpace(constraint) == "lts" {
+		cycle, ok := d.lifecycle.Lookup(runtime.Name, runtime.Version)
+		if !ok {
+			return fmt.Sprintf("%s %s has no lifecycle data to confirm an LTS release", runtime.Name, runtime.Version)
+		}
+		now := time.Now()
+		if !cycle.IsLTS(now) {
+			return fmt.Sprintf("%s %s is not an LTS release", runtime.Name, runtime.Version)
+		}
+		if cycle.IsEOL(now) {
+			return fmt.Sprintf("%s %s is an LTS release past its end of life", runtime.Name, runtime.Version)
+		}
+		return ""
+	}
+
+	if c, _ := version.ParseConstraint(constraint); !c.Matches(runtime.Version) {
+		return fmt.Sprintf("%s %s does not satisfy %s", runtime.Name, runtime.Version, constraint)
+	}
+	return ""
+}
+
+// fromRegistry reports whether an image is pulled from one of the allowed registries.
+// Images without a registry host come from Docker Hub, i.e. docker.io.
+func fromRegistry(image string, registries []string) bool {
+	if host, _, ok := strings.Cut(image, "/"); !ok || !(strings.ContainsAny(host, ".:") || host == "localhost") {
+		image = "docker.io/" + image
+	}
+	for _, registry := range registries {
+		registry = strings.TrimSuffix(registry, "/")
+		if strings.HasPrefix(image, registry+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// languageFiles lists the file indicators of a language present in a project
+func (d *Detector) languageFiles(path, language string) []string {
+	return d.existingFiles(path, d.config[language].FileIndicators)
+}
+
+// toolFiles lists the files selecting a build tool or package manager, falling
+// back to the language's file indicators
+func (d *Detector) toolFiles(path, language, tool string) []string {
+	if files := d.existingFiles(path, buildToolFiles[tool]); len(files) > 0 {
+		return files
+	}
+	return d.languageFiles(path, language)
+}
+
+// existingFiles lists the files of a project matching the given patterns
+func (d *Detector) existingFiles(path string, patterns []string) []string {
+	var files []string
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(filepath.Join(path, pattern))
+		for _, match := range matches {
+			if rel, err := filepath.Rel(path, match); err == nil && !slices.Contains(files, filepath.ToSlash(rel)) {
+				files = append(files, filepath.ToSlash(rel))
+			}
+		}
+	}
+	return files
+}
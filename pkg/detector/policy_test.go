@@ -0,0 +1,150 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	detector := NewDetector()
+
+	tests := []struct {
+		name     string
+		policy   string
+		files    map[string]string
+		expected []Violation
+	}{
+		{
+			name:   "Runtime below minimum version",
+			policy: "runtimes:\n  java: \">=17\"\n",
+			files: map[string]string{
+				"pom.xml":       "<project><properties><maven.compiler.release>11</maven.compiler.release></properties></project>",
+				".java-version": "11\n",
+			},
+			expected: []Violation{{Rule: "runtimes.java", Message: "jvm 11 does not satisfy >=17", Files: []string{"pom.xml", ".java-version"}}},
+		},
+		{
+			name:   "Runtime within a version range",
+			policy: "runtimes:\n  jvm: \">=8, <11\"\n",
+			files: map[string]string{
+				"pom.xml":       "<project></project>",
+				".java-version": "1.8\n",
+			},
+			expected: nil,
+		},
+		{
+			name:     "Denied build tool",
+			policy:   "build_tools:\n  deny: [pipenv]\n",
+			files:    map[string]string{"Pipfile": "[packages]\nflask = \"*\"\n"},
+			expected: []Violation{{Rule: "build_tools", Message: "build tool pipenv is not allowed", Files: []string{"Pipfile"}}},
+		},
+		{
+			name:     "Language not allowed",
+			policy:   "languages:\n  allow: [java, go]\n",
+			files:    map[string]string{"Gemfile": "source \"https://rubygems.org\"\n"},
+			expected: []Violation{{Rule: "languages", Message: "language ruby is not allowed", Files: []string{"Gemfile"}}},
+		},
+		{
+			name:     "Node release that is not LTS",
+			policy:   "runtimes:\n  node: lts\n",
+			files:    map[string]string{"package.json": "{}", ".nvmrc": "21\n"},
+			expected: []Violation{{Rule: "runtimes.node", Message: "node 21 is not an LTS release", Files: []string{".nvmrc"}}},
+		},
+		{
+			name:     "Node LTS release past its end of life",
+			policy:   "runtimes:\n  node: lts\n",
+			files:    map[string]string{"package.json": "{}", ".nvmrc": "18\n"},
+			expected: []Violation{{Rule: "runtimes.node", Message: "node 18 is an LTS release past its end of life", Files: []string{".nvmrc"}}},
+		},
+		{
+			name:     "Supported Node LTS release",
+			policy:   "runtimes:\n  node: lts\n",
+			files:    map[string]string{"package.json": "{}", ".nvmrc": "22\n"},
+			expected: nil,
+		},
+		{
+			name:     "Runtime version not pinned",
+			policy:   "runtimes:\n  jvm: \">=21\"\n",
+			files:    map[string]string{"pom.xml": "<project></project>"},
+			expected: []Violation{{Rule: "runtimes.jvm", Message: "jvm version is not pinned", Files: []string{"pom.xml"}}},
+		},
+		{
+			name:     "Denied Leiningen lists project.clj",
+			policy:   "build_tools:\n  deny: [lein]\n",
+			files:    map[string]string{"project.clj": "(defproject app \"0.1.0\")\n"},
+			expected: []Violation{{Rule: "build_tools", Message: "build tool lein is not allowed", Files: []string{"project.clj"}}},
+		},
+		{
+			name:   "Images from an allowed registry",
+			policy: "images:\n  registries: [registry.corp]\n",
+			files: map[string]string{
+				"go.mod":     "module example.com/app\n\ngo 1.24\n",
+				"Dockerfile": "FROM registry.corp/golang:1.24 AS build\nFROM golang:1.24-alpine\n",
+			},
+			expected: []Violation{{Rule: "images.registries", Message: "image golang:1.24-alpine is not from an allowed registry", Files: []string{"Dockerfile"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			for filename, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			}
+			policyFile := filepath.Join(tmpDir, "policy.yaml")
+			if err := os.WriteFile(policyFile, []byte(tt.policy), 0644); err != nil {
+				t.Fatalf("Failed to create policy file: %v", err)
+			}
+
+			policy, err := LoadPolicy(policyFile)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			violations, err := detector.Check(tmpDir, policy)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(violations, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, violations)
+			}
+		})
+	}
+}
+
+func TestLoadPolicyInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+	}{
+		{"Invalid constraint", "runtimes:\n  python: \"newest\"\n"},
+		{"Misspelled key", "build_tool:\n  deny: [pipenv]\n"},
+		{"Misspelled nested key", "images:\n  registry: [registry.corp]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			policyFile := filepath.Join(tmpDir, "policy.yaml")
+			if err := os.WriteFile(policyFile, []byte(tt.policy), 0644); err != nil {
+				t.Fatalf("Failed to create policy file: %v", err)
+			}
+			if _, err := LoadPolicy(policyFile); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,460 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Project is a detected project of a repository, at a path relative to its root
+type Project struct {
+	Path  string
+	Stack *models.TechStack
+}
+
+// ciSetup is the GitHub Actions step that installs a runtime
+type ciSetup struct {
+	Action           string
+	Version          string            // Input taking the runtime version
+	VersionFormat    string            // Formats the version for the input, e.g. "%s.x"; "%s" when empty
+	LanguageVersions map[string]string // Inputs taking the language version, for runtimes hosting several languages
+	With             map[string]string // Fixed inputs
+}
+
+// githubSetups maps runtimes to their setup actions. Runtimes without one run in
+// their CI image as a job container.
+var githubSetups = map[string]ciSetup{
+	"python":  {Action: "actions/setup-python@v5", Version: "python-version"},
+	"node":    {Action: "actions/setup-node@v4", Version: "node-version"},
+	"jvm":     {Action: "actions/setup-java@v4", Version: "java-version", With: map[string]string{"distribution": "temurin"}},
+	"go":      {Action: "actions/setup-go@v5", Version: "go-version", With: map[string]string{"cache": "false"}},
+	"dotnet":  {Action: "actions/setup-dotnet@v4", Version: "dotnet-version", VersionFormat: "%s.x"},
+	"ruby":    {Action: "ruby/setup-ruby@v1", Version: "ruby-version"},
+	"php":     {Action: "shivammathur/setup-php@v2", Version: "php-version"},
+	"otp":     {Action: "erlef/setup-beam@v1", Version: "otp-version", LanguageVersions: map[string]string{"elixir": "elixir-version"}},
+	"rust":    {Action: "dtolnay/rust-toolchain@master", Version: "toolchain"},
+	"deno":    {Action: "denoland/setup-deno@v2", Version: "deno-version"},
+	"bun":     {Action: "oven-sh/setup-bun@v2", Version: "bun-version"},
+	"dart":    {Action: "dart-lang/setup-dart@v1", Version: "sdk"},
+	"flutter": {Action: "subosito/flutter-action@v2", Version: "flutter-version"},
+	"swift":   {Action: "swift-actions/setup-swift@v2", Version: "swift-version"},
+	"ghc":     {Action: "haskell-actions/setup@v2", Version: "ghc-version"},
+	"ocaml":   {Action: "ocaml/setup-ocaml@v3", Version: "ocaml-compiler"},
+	"zig":     {Action: "mlugg/setup-zig@v2", Version: "version"},
+	"nim":     {Action: "jiro4989/setup-nim-action@v2", Version: "nim-version"},
+	"crystal": {Action: "crystal-lang/install-crystal@v1", Version: "crystal"},
+	"r":       {Action: "r-lib/actions/setup-r@v2", Version: "r-version"},
+	"julia":   {Action: "julia-actions/setup-julia@v2", Version: "version"},
+	"perl":    {Action: "shogo82148/actions-setup-perl@v1", Version: "perl-version"},
+	"lua":     {Action: "leafo/gh-actions-lua@v10", Version: "luaVersion"},
+	"bash":    {}, // Runners ship bash
+	"alpine":  {},
+}
+
+// ciCache describes the dependency cache of a build tool
+type ciCache struct {
+	Lockfiles []string          // Files whose content keys the cache
+	Paths     []string          // Cache directories in the home directory of GitHub-hosted runners
+	Local     []string          // Cache directories inside the project
+	Vars      map[string]string // Variables making the tool use Local
+
+	// GitLab only caches paths inside the project, so this variable moves the
+	// Paths cache there; %s is the directory
+	CacheEnv string
+}
+
+// ciCaches maps build tools to their dependency caches
+var ciCaches = map[string]ciCache{
+	"npm":      {Lockfiles: []string{"package-lock.json"}, Paths: []string{"~/.npm"}, CacheEnv: "npm_config_cache=%s"},
+	"yarn":     {Lockfiles: []string{"yarn.lock"}, Paths: []string{"~/.cache/yarn"}, CacheEnv: "YARN_CACHE_FOLDER=%s"},
+	"pnpm":     {Lockfiles: []string{"pnpm-lock.yaml"}, Paths: []string{"~/.local/share/pnpm/store"}, CacheEnv: "npm_config_store_dir=%s"},
+	"bun":      {Lockfiles: []string{"bun.lock", "bun.lockb"}, Paths: []string{"~/.bun/install/cache"}, CacheEnv: "BUN_INSTALL_CACHE_DIR=%s"},
+	"pip":      {Lockfiles: []string{"requirements.txt"}, Paths: []string{"~/.cache/pip"}, CacheEnv: "PIP_CACHE_DIR=%s"},
+	"poetry":   {Lockfiles: []string{"poetry.lock"}, Paths: []string{"~/.cache/pypoetry"}, CacheEnv: "POETRY_CACHE_DIR=%s"},
+	"pdm":      {Lockfiles: []string{"pdm.lock"}, Paths: []string{"~/.cache/pdm"}, CacheEnv: "PDM_CACHE_DIR=%s"},
+	"pipenv":   {Lockfiles: []string{"Pipfile.lock"}, Paths: []string{"~/.cache/pipenv"}, CacheEnv: "PIPENV_CACHE_DIR=%s"},
+	"uv":       {Lockfiles: []string{"uv.lock"}, Paths: []string{"~/.cache/uv"}, CacheEnv: "UV_CACHE_DIR=%s"},
+	"maven":    {Lockfiles: []string{"pom.xml"}, Paths: []string{"~/.m2/repository"}, CacheEnv: "MAVEN_OPTS=-Dmaven.repo.local=%s"},
+	"gradle":   {Lockfiles: []string{"build.gradle", "build.gradle.kts", "gradle/libs.versions.toml"}, Paths: []string{"~/.gradle/caches", "~/.gradle/wrapper"}, CacheEnv: "GRADLE_USER_HOME=%s"},
+	"sbt":      {Lockfiles: []string{"build.sbt", "project/build.properties"}, Paths: []string{"~/.cache/coursier", "~/.sbt"}, CacheEnv: "COURSIER_CACHE=%s"},
+	"go":       {Lockfiles: []string{"go.sum"}, Paths: []string{"~/go/pkg/mod", "~/.cache/go-build"}, CacheEnv: "GOMODCACHE=%s"},
+	"cargo":    {Lockfiles: []string{"Cargo.lock"}, Paths: []string{"~/.cargo/registry", "~/.cargo/git"}, Local: []string{"target"}, CacheEnv: "CARGO_HOME=%s"},
+	"bundle":   {Lockfiles: []string{"Gemfile.lock"}, Local: []string{"vendor/bundle"}, Vars: map[string]string{"BUNDLE_PATH": "vendor/bundle"}},
+	"composer": {Lockfiles: []string{"composer.lock"}, Paths: []string{"~/.cache/composer"}, CacheEnv: "COMPOSER_CACHE_DIR=%s"},
+	"dotnet":   {Lockfiles: []string{"packages.lock.json", "Directory.Packages.props"}, Paths: []string{"~/.nuget/packages"}, CacheEnv: "NUGET_PACKAGES=%s"},
+	"mix":      {Lockfiles: []string{"mix.lock"}, Local: []string{"deps", "_build"}},
+	"dart":     {Lockfiles: []string{"pubspec.lock"}, Paths: []string{"~/.pub-cache"}, CacheEnv: "PUB_CACHE=%s"},
+	"flutter":  {Lockfiles: []string{"pubspec.lock"}, Paths: []string{"~/.pub-cache"}, CacheEnv: "PUB_CACHE=%s"},
+	"swift":    {Lockfiles: []string{"Package.resolved"}, Local: []string{".build"}},
+	"stack":    {Lockfiles: []string{"stack.yaml.lock"}, Paths: []string{"~/.stack"}, CacheEnv: "STACK_ROOT=%s"},
+}
+
+// ciToolSetup installs build tools that runtime setup actions and CI images lack
+var ciToolSetup = map[string]string{
+	"yarn":   "corepack enable",
+	"pnpm":   "corepack enable",
+	"poetry": "pip install poetry",
+	"pdm":    "pip install pdm",
+	"pipenv": "pip install pipenv",
+	"uv":     "pip install uv",
+	"hatch":  "pip install hatch",
+}
+
+// gitlabToolSetup installs build tools missing from CI images that GitHub setup actions bring along
+var gitlabToolSetup = map[string]string{
+	"composer": "curl -sS https://getcomposer.org/installer | php -- --install-dir=/usr/local/bin --filename=composer",
+}
+
+type githubWorkflow struct {
+	Name string                `yaml:"name"`
+	On   githubTriggers        `yaml:"on"`
+	Jobs map[string]*githubJob `yaml:"jobs"`
+}
+
+type githubTriggers struct {
+	Push struct {
+		Branches []string `yaml:"branches"`
+	} `yaml:"push"`
+	PullRequest struct{} `yaml:"pull_request"`
+}
+
+type githubJob struct {
+	RunsOn    string            `yaml:"runs-on"`
+	Container string            `yaml:"container,omitempty"`
+	Strategy  *githubStrategy   `yaml:"strategy,omitempty"`
+	Defaults  *githubDefaults   `yaml:"defaults,omitempty"`
+	Env       map[string]string `yaml:"env,omitempty"`
+	Steps     []githubStep      `yaml:"steps"`
+}
+
+type githubStrategy struct {
+	FailFast bool `yaml:"fail-fast"`
+	Matrix   struct {
+		Include []map[string]string `yaml:"include"`
+	} `yaml:"matrix"`
+}
+
+type githubDefaults struct {
+	Run struct {
+		WorkingDirectory string `yaml:"working-directory"`
+	} `yaml:"run"`
+}
+
+type githubStep struct {
+	Name string            `yaml:"name,omitempty"`
+	If   string            `yaml:"if,omitempty"`
+	Uses string            `yaml:"uses,omitempty"`
+	With map[string]string `yaml:"with,omitempty"`
+	Run  string            `yaml:"run,omitempty"`
+}
+
+// GitHubWorkflow renders a GitHub Actions workflow building and testing the given
+// projects. Projects sharing a language and build tool share a job; projects
+// below the repository root run as a matrix over their directories.
+func GitHubWorkflow(projects []Project) (string, error) {
+	groups, err := groupProjects(projects)
+	if err != nil {
+		return "", err
+	}
+
+	workflow := githubWorkflow{Name: "CI", Jobs: make(map[string]*githubJob)}
+	workflow.On.Push.Branches = []string{"main"}
+	for _, group := range groups {
+		id := group[0].Stack.Language.Name
+		if _, taken := workflow.Jobs[id]; taken {
+			id += "-" + group[0].Stack.Language.BuildTool
+		}
+		workflow.Jobs[id] = githubJobFor(group)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(workflow); err != nil {
+		return "", fmt.Errorf("failed to marshal workflow: %w", err)
+	}
+	// yaml.v3 quotes the on key, which YAML 1.1 reads as a boolean; GitHub does not
+	return "# Generated by stackradar generate ci\n" + strings.Replace(buf.String(), `"on":`, "on:", 1), nil
+}
+
+// githubJobFor builds the job for projects sharing a language and build tool. A
+// single project at the root gets literal values; otherwise each value comes
+// from the matrix entry of the project.
+func githubJobFor(group []Project) *githubJob {
+	lang := group[0].Stack.Language
+	setup, hasSetup := githubSetups[lang.Runtime.Name]
+	languageVersionInput := setup.LanguageVersions[lang.Name]
+	matrix := len(group) > 1 || group[0].Path != "."
+
+	entries := make([]map[string]string, len(group))
+	for i, project := range group {
+		l := project.Stack.Language
+		commands := projectCommands(project.Stack)
+		entry := map[string]string{
+			"project": filepath.ToSlash(project.Path),
+			"version": l.Runtime.Version,
+			"install": commands.Install,
+			"build":   commands.Build,
+			"test":    commands.Test,
+		}
+		if !hasSetup {
+			entry["image"] = l.CIImageTag
+		}
+		if languageVersionInput != "" {
+			entry["language-version"] = l.LanguageVersion
+		}
+		maps.DeleteFunc(entry, func(_, v string) bool { return v == "" })
+		entries[i] = entry
+	}
+
+	// value returns a literal for a single project, or a matrix reference
+	value := func(key string) string {
+		if !matrix {
+			return entries[0][key]
+		}
+		return "${{ matrix." + key + " }}"
+	}
+	// present reports whether some project has a value, and whether all do
+	present := func(key string) (some, all bool) {
+		all = true
+		for _, entry := range entries {
+			_, ok := entry[key]
+			some = some || ok
+			all = all && ok
+		}
+		return some, all
+	}
+
+	job := &githubJob{RunsOn: "ubuntu-latest"}
+	if matrix {
+		job.Strategy = &githubStrategy{}
+		job.Strategy.Matrix.Include = entries
+		job.Defaults = &githubDefaults{}
+		job.Defaults.Run.WorkingDirectory = value("project")
+	}
+	job.Steps = append(job.Steps, githubStep{Uses: "actions/checkout@v4"})
+
+	switch {
+	case !hasSetup:
+		job.Container = value("image")
+	case setup.Action != "":
+		versionFormat := setup.VersionFormat
+		if versionFormat == "" {
+			versionFormat = "%s"
+		}
+		step := githubStep{Uses: setup.Action, With: map[string]string{setup.Version: fmt.Sprintf(versionFormat, value("version"))}}
+		maps.Copy(step.With, setup.With)
+		if some, _ := present("language-version"); some {
+			step.With[languageVersionInput] = value("language-version")
+		}
+		job.Steps = append(job.Steps, step)
+	}
+	if command, ok := ciToolSetup[lang.BuildTool]; ok {
+		job.Steps = append(job.Steps, githubStep{Run: command})
+	}
+
+	if cache, ok := ciCaches[lang.BuildTool]; ok {
+		paths := slices.Clone(cache.Paths)
+		for _, dir := range cache.Local {
+			paths = append(paths, projectFile(matrix, group[0].Path, dir))
+		}
+		prefix := "${{ runner.os }}-" + lang.BuildTool + "-"
+		var lockfiles []string
+		for _, file := range cache.Lockfiles {
+			if matrix {
+				lockfiles = append(lockfiles, fmt.Sprintf("format('{0}/%s', matrix.project)", file))
+			} else {
+				lockfiles = append(lockfiles, "'"+projectFile(matrix, group[0].Path, file)+"'")
+			}
+		}
+		if matrix {
+			prefix += "${{ matrix.project }}-"
+		}
+		job.Steps = append(job.Steps, githubStep{
+			Uses: "actions/cache@v4",
+			With: map[string]string{
+				"path":         strings.Join(paths, "\n"),
+				"key":          prefix + "${{ hashFiles(" + strings.Join(lockfiles, ", ") + ") }}",
+				"restore-keys": prefix,
+			},
+		})
+		if len(cache.Vars) > 0 {
+			job.Env = cache.Vars
+		}
+	}
+
+	for _, step := range []struct{ name, key string }{{"Install", "install"}, {"Build", "build"}, {"Test", "test"}} {
+		some, all := present(step.key)
+		if !some {
+			continue
+		}
+		s := githubStep{Name: step.name, Run: value(step.key)}
+		if !all {
+			s.If = "matrix." + step.key + " != ''"
+		}
+		job.Steps = append(job.Steps, s)
+	}
+	return job
+}
+
+// projectFile returns a path inside the project for a job running one project.
+// Matrix jobs resolve it through the matrix instead.
+func projectFile(matrix bool, project, file string) string {
+	if matrix {
+		return "${{ matrix.project }}/" + file
+	}
+	return filepath.ToSlash(filepath.Join(project, file))
+}
+
+type gitlabJob struct {
+	Image        string            `yaml:"image"`
+	Variables    map[string]string `yaml:"variables,omitempty"`
+	Cache        *gitlabCache      `yaml:"cache,omitempty"`
+	Rules        []gitlabRule      `yaml:"rules,omitempty"`
+	BeforeScript []string          `yaml:"before_script,omitempty"`
+	Script       []string          `yaml:"script"`
+}
+
+type gitlabCache struct {
+	Key   any      `yaml:"key"`
+	Paths []string `yaml:"paths"`
+}
+
+type gitlabCacheKey struct {
+	Files  []string `yaml:"files"`
+	Prefix string   `yaml:"prefix,omitempty"`
+}
+
+type gitlabRule struct {
+	Changes []string `yaml:"changes"`
+}
+
+// GitLabCI renders a .gitlab-ci.yml with one job per project, running in the
+// project's CI image. Jobs for projects below the repository root only run when
+// the project changes. root locates the lockfiles that key the caches.
+func GitLabCI(root string, projects []Project) (string, error) {
+	groups, err := groupProjects(projects)
+	if err != nil {
+		return "", err
+	}
+
+	jobs := make(map[string]*gitlabJob)
+	for _, group := range groups {
+		for _, project := range group {
+			name := filepath.ToSlash(project.Path)
+			if name == "." {
+				name = project.Stack.Language.Name
+			}
+			jobs[name] = gitlabJobFor(root, project)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(jobs); err != nil {
+		return "", fmt.Errorf("failed to marshal pipeline: %w", err)
+	}
+	return "# Generated by stackradar generate ci\n" + buf.String(), nil
+}
+
+// gitlabJobFor builds the job for a single project
+func gitlabJobFor(root string, project Project) *gitlabJob {
+	lang := project.Stack.Language
+	dir := filepath.ToSlash(project.Path)
+	inProject := func(file string) string { return filepath.ToSlash(filepath.Join(dir, file)) }
+
+	job := &gitlabJob{Image: lang.CIImageTag}
+	if dir != "." {
+		job.Rules = []gitlabRule{{Changes: []string{dir + "/**/*"}}}
+		job.BeforeScript = append(job.BeforeScript, "cd "+dir)
+	}
+	if command, ok := gitlabToolSetup[lang.BuildTool]; ok {
+		job.BeforeScript = append(job.BeforeScript, command)
+	}
+	if command, ok := ciToolSetup[lang.BuildTool]; ok {
+		job.BeforeScript = append(job.BeforeScript, command)
+	}
+
+	if cache, ok := ciCaches[lang.BuildTool]; ok {
+		job.Cache = &gitlabCache{}
+		job.Variables = make(map[string]string)
+		if cache.CacheEnv != "" {
+			cacheDir := inProject(".cache/" + lang.BuildTool)
+			name, v, _ := strings.Cut(fmt.Sprintf(cache.CacheEnv, "$CI_PROJECT_DIR/"+cacheDir), "=")
+			job.Variables[name] = v
+			job.Cache.Paths = append(job.Cache.Paths, cacheDir)
+		}
+		for name, v := range cache.Vars {
+			job.Variables[name] = v
+		}
+		for _, local := range cache.Local {
+			job.Cache.Paths = append(job.Cache.Paths, inProject(local))
+		}
+
+		// cache:key:files takes at most two files, which must exist to key anything
+		var files []string
+		for _, file := range existingFiles(filepath.Join(root, project.Path), cache.Lockfiles) {
+			if len(files) < 2 {
+				files = append(files, inProject(file))
+			}
+		}
+		if len(files) > 0 {
+			job.Cache.Key = gitlabCacheKey{Files: files, Prefix: lang.BuildTool}
+		} else {
+			job.Cache.Key = lang.BuildTool + "-$CI_COMMIT_REF_SLUG"
+		}
+		if len(job.Variables) == 0 {
+			job.Variables = nil
+		}
+	}
+
+	commands := projectCommands(project.Stack)
+	for _, command := range []string{commands.Install, commands.Build, commands.Test} {
+		if command != "" {
+			job.Script = append(job.Script, command)
+		}
+	}
+	return job
+}
+
+// groupProjects groups projects by language and build tool, in path order,
+// skipping infrastructure-only projects
+func groupProjects(projects []Project) ([][]Project, error) {
+	projects = slices.Clone(projects)
+	slices.SortFunc(projects, func(a, b Project) int { return strings.Compare(a.Path, b.Path) })
+
+	var groups [][]Project
+	index := make(map[string]int)
+	for _, project := range projects {
+		lang := project.Stack.Language
+		if lang.Name == "" {
+			continue
+		}
+		key := lang.Name + "/" + lang.BuildTool
+		if i, ok := index[key]; ok {
+			groups[i] = append(groups[i], project)
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, []Project{project})
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("no project with a detected language to build")
+	}
+	return groups, nil
+}
+
+// projectCommands returns the suggested commands of a stack, if any
+func projectCommands(ts *models.TechStack) models.Commands {
+	if ts.Commands == nil {
+		return models.Commands{}
+	}
+	return *ts.Commands
+}
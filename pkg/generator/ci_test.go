@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+func goStack(version string) *models.TechStack {
+	return &models.TechStack{
+		Language: models.Language{Name: "go", BuildTool: "go", Runtime: models.Runtime{Name: "go", Version: version}, CIImageTag: "golang:" + version + "-alpine"},
+		Commands: &models.Commands{Install: "go mod download", Build: "go build ./...", Test: "go test ./..."},
+	}
+}
+
+func TestGitHubWorkflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		projects []Project
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "Single project",
+			projects: []Project{{Path: ".", Stack: goStack("1.22")}},
+			expected: []string{
+				"\non:\n",
+				"      - uses: actions/setup-go@v5\n        with:\n          cache: \"false\"\n          go-version: \"1.22\"\n",
+				"key: ${{ runner.os }}-go-${{ hashFiles('go.sum') }}\n",
+				"        run: go build ./...\n",
+				"        run: go test ./...\n",
+			},
+			absent: []string{"matrix", "working-directory"},
+		},
+		{
+			name: "Monorepo matrix",
+			projects: []Project{
+				{Path: "services/b", Stack: goStack("1.23")},
+				{Path: "services/a", Stack: &models.TechStack{
+					Language: models.Language{Name: "go", BuildTool: "go", Runtime: models.Runtime{Name: "go", Version: "1.22"}},
+					Commands: &models.Commands{Install: "go mod download", Build: "go build ./..."},
+				}},
+			},
+			expected: []string{
+				"          - build: go build ./...\n            install: go mod download\n            project: services/a\n            version: \"1.22\"\n",
+				"            project: services/b\n            test: go test ./...\n            version: \"1.23\"\n",
+				"working-directory: ${{ matrix.project }}\n",
+				"go-version: ${{ matrix.version }}\n",
+				"hashFiles(format('{0}/go.sum', matrix.project))",
+				"if: matrix.test != ''\n",
+			},
+		},
+		{
+			name: "Runtime without a setup action runs in its CI image",
+			projects: []Project{{Path: ".", Stack: &models.TechStack{
+				Language: models.Language{Name: "gleam", BuildTool: "gleam", Runtime: models.Runtime{Name: "beam-gleam", Version: "1.0"}, CIImageTag: "ghcr.io/gleam-lang/gleam:v1.0.0-erlang-alpine"},
+				Commands: &models.Commands{Test: "gleam test"},
+			}}},
+			expected: []string{"container: ghcr.io/gleam-lang/gleam:v1.0.0-erlang-alpine\n", "run: gleam test\n"},
+			absent:   []string{"actions/cache"},
+		},
+		{
+			name: "Build tool installed after the runtime",
+			projects: []Project{{Path: ".", Stack: &models.TechStack{
+				Language: models.Language{Name: "python", BuildTool: "poetry", Runtime: models.Runtime{Name: "python", Version: "3.12"}},
+				Commands: &models.Commands{Install: "poetry install --no-interaction"},
+			}}},
+			expected: []string{"python-version: \"3.12\"\n      - run: pip install poetry\n", "path: ~/.cache/pypoetry\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflow, err := GitHubWorkflow(tt.projects)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(workflow, expected) {
+					t.Errorf("Expected workflow to contain %q, got:\n%s", expected, workflow)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(workflow, absent) {
+					t.Errorf("Expected workflow not to contain %q, got:\n%s", absent, workflow)
+				}
+			}
+		})
+	}
+}
+
+func TestGitLabCI(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, "api"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "api", "go.sum"), nil, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		projects []Project
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "Project at the root without a lockfile",
+			projects: []Project{{Path: ".", Stack: goStack("1.22")}},
+			expected: []string{
+				"go:\n  image: golang:1.22-alpine\n",
+				"GOMODCACHE: $CI_PROJECT_DIR/.cache/go\n",
+				"key: go-$CI_COMMIT_REF_SLUG\n",
+				"  script:\n    - go mod download\n    - go build ./...\n    - go test ./...\n",
+			},
+			absent: []string{"rules", "before_script"},
+		},
+		{
+			name:     "Subproject keyed by its lockfile",
+			projects: []Project{{Path: "api", Stack: goStack("1.23")}},
+			expected: []string{
+				"api:\n  image: golang:1.23-alpine\n",
+				"GOMODCACHE: $CI_PROJECT_DIR/api/.cache/go\n",
+				"      files:\n        - api/go.sum\n      prefix: go\n",
+				"    - changes:\n        - api/**/*\n",
+				"  before_script:\n    - cd api\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, err := GitLabCI(tmpDir, tt.projects)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(pipeline, expected) {
+					t.Errorf("Expected pipeline to contain %q, got:\n%s", expected, pipeline)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(pipeline, absent) {
+					t.Errorf("Expected pipeline not to contain %q, got:\n%s", absent, pipeline)
+				}
+			}
+		})
+	}
+}
+
+func TestCIWithoutLanguage(t *testing.T) {
+	projects := []Project{{Path: ".", Stack: &models.TechStack{IaC: []models.IaCTool{{Name: "terraform"}}}}}
+	if _, err := GitHubWorkflow(projects); err == nil {
+		t.Error("Expected an error for GitHub Actions")
+	}
+	if _, err := GitLabCI(".", projects); err == nil {
+		t.Error("Expected an error for GitLab CI")
+	}
+}
@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/catalog"
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+// devcontainerBase is the image runtimes are installed on through features when
+// no devcontainer image publishes their version
+const devcontainerBase = "mcr.microsoft.com/devcontainers/base:ubuntu"
+
+// devcontainerRuntime describes how a dev container provides a runtime
+type devcontainerRuntime struct {
+	Image          string            // Devcontainer image template; %s is the runtime version
+	LanguageImages map[string]string // Image templates for languages with a dedicated image
+	Feature        string            // Feature installing the runtime at a version onto the base image
+	Options        map[string]string // Fixed feature options
+}
+
+// devcontainerRuntimes maps runtimes to devcontainer images and features. Runtimes
+// missing from the map run in their CI image.
+var devcontainerRuntimes = map[string]devcontainerRuntime{
+	"python": {Image: "mcr.microsoft.com/devcontainers/python:%s", Feature: "ghcr.io/devcontainers/features/python:1"},
+	"node": {
+		Image:          "mcr.microsoft.com/devcontainers/javascript-node:%s",
+		LanguageImages: map[string]string{"typescript": "mcr.microsoft.com/devcontainers/typescript-node:%s"},
+		Feature:        "ghcr.io/devcontainers/features/node:1",
+	},
+	"jvm":    {Image: "mcr.microsoft.com/devcontainers/java:%s", Feature: "ghcr.io/devcontainers/features/java:1", Options: map[string]string{"jdkDistro": "tem"}},
+	"go":     {Image: "mcr.microsoft.com/devcontainers/go:%s", Feature: "ghcr.io/devcontainers/features/go:1"},
+	"dotnet": {Image: "mcr.microsoft.com/devcontainers/dotnet:%s", Feature: "ghcr.io/devcontainers/features/dotnet:2"},
+	"ruby":   {Image: "mcr.microsoft.com/devcontainers/ruby:%s", Feature: "ghcr.io/devcontainers/features/ruby:1"},
+	"php":    {Image: "mcr.microsoft.com/devcontainers/php:%s", Feature: "ghcr.io/devcontainers/features/php:1"},
+	"rust":   {Feature: "ghcr.io/devcontainers/features/rust:1"}, // The rust image only publishes the latest toolchain
+}
+
+// devcontainerTool installs a build tool in a dev container, through a feature or
+// a command run after the container is created
+type devcontainerTool struct {
+	Feature string
+	Options map[string]string
+	Command string
+}
+
+// devcontainerTools holds the build tools that devcontainer images lack
+var devcontainerTools = map[string]devcontainerTool{
+	"poetry": {Feature: "ghcr.io/devcontainers-extra/features/poetry:2"},
+	"pnpm":   {Feature: "ghcr.io/devcontainers-extra/features/pnpm:2"},
+	"gradle": {Feature: "ghcr.io/devcontainers/features/java:1", Options: map[string]string{"version": "none", "installGradle": "true"}},
+	"maven":  {Feature: "ghcr.io/devcontainers/features/java:1", Options: map[string]string{"version": "none", "installMaven": "true"}},
+	"uv":     {Command: "pipx install uv"},
+	"pdm":    {Command: "pipx install pdm"},
+	"pipenv": {Command: "pipx install pipenv"},
+	"hatch":  {Command: "pipx install hatch"},
+}
+
+type devcontainer struct {
+	Name              string                       `json:"name"`
+	Image             string                       `json:"image"`
+	Features          map[string]map[string]string `json:"features,omitempty"`
+	PostCreateCommand string                       `json:"postCreateCommand,omitempty"`
+}
+
+// Devcontainer generates a .devcontainer/devcontainer.json that provides the
+// detected runtime version and build tool, so the development environment
+// matches the CI image. Runtimes with a devcontainer image use it at the
+// runtime version; others are installed through a feature, and runtimes with
+// neither run in ci_image_tag. Devcontainer image tags are resolved in the
+// catalog the stack was detected with. The install command runs once the
+// container is created.
+func Devcontainer(path string, ts *models.TechStack, c *catalog.Catalog) (string, error) {
+	lang := ts.Language
+	if lang.Name == "" {
+		return "", fmt.Errorf("no language detected to set up a dev container for")
+	}
+
+	name := filepath.Base(path)
+	if abs, err := filepath.Abs(path); err == nil {
+		name = filepath.Base(abs)
+	}
+	config := devcontainer{Name: name, Features: make(map[string]map[string]string)}
+
+	runtime, hasRuntime := devcontainerRuntimes[lang.Runtime.Name]
+	image := devcontainerImage(c, runtime, lang)
+	switch {
+	case !hasRuntime:
+		config.Image = lang.CIImageTag
+	case image != "":
+		config.Image = image
+	default:
+		config.Image = devcontainerBase
+		version := lang.Runtime.Version
+		if version == "" {
+			version = "latest"
+		}
+		config.Features[runtime.Feature] = map[string]string{"version": version}
+		maps.Copy(config.Features[runtime.Feature], runtime.Options)
+	}
+
+	var commands []string
+	tool, hasTool := devcontainerTools[lang.BuildTool]
+	switch {
+	case !hasRuntime:
+		// Features need a devcontainer image, so CI images get the build tool as in CI
+		if command, ok := ciToolSetup[lang.BuildTool]; ok {
+			commands = append(commands, command)
+		}
+	case !hasTool:
+	case tool.Command != "":
+		commands = append(commands, tool.Command)
+	default:
+		// Options of a feature the runtime already added take precedence
+		options := config.Features[tool.Feature]
+		if options == nil {
+			options = make(map[string]string)
+			config.Features[tool.Feature] = options
+		}
+		for key, value := range tool.Options {
+			if _, set := options[key]; !set {
+				options[key] = value
+			}
+		}
+	}
+	if len(config.Features) == 0 {
+		config.Features = nil
+	}
+
+	if install := projectCommands(ts).Install; install != "" {
+		commands = append(commands, install)
+	}
+	config.PostCreateCommand = strings.Join(commands, " && ")
+
+	// Commands are joined with &&, which the default encoder escapes
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(config); err != nil {
+		return "", fmt.Errorf("failed to marshal devcontainer: %w", err)
+	}
+	return "// Generated by stackradar generate devcontainer\n" + buf.String(), nil
+}
+
+// devcontainerImage returns the devcontainer image of a runtime at the detected
+// version, or an empty string when no image publishes that version
+func devcontainerImage(c *catalog.Catalog, runtime devcontainerRuntime, lang models.Language) string {
+	template := runtime.Image
+	if languageTemplate, ok := runtime.LanguageImages[lang.Name]; ok {
+		template = languageTemplate
+	}
+	if template == "" || lang.Runtime.Version == "" {
+		return ""
+	}
+	image, err := c.Resolve(template, lang.Runtime.Version)
+	if errors.Is(err, catalog.ErrNoMatchingTag) {
+		return ""
+	}
+	return image
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stack-radar/stackradar/pkg/catalog"
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+func TestDevcontainer(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     models.Language
+		commands *models.Commands
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "Devcontainer image at the runtime version",
+			lang:     models.Language{Name: "python", BuildTool: "poetry", Runtime: models.Runtime{Name: "python", Version: "3.12.4"}, CIImageTag: "python:3.12-slim"},
+			commands: &models.Commands{Install: "poetry install --no-interaction"},
+			expected: []string{
+				`"image": "mcr.microsoft.com/devcontainers/python:3.12"`,
+				`"ghcr.io/devcontainers-extra/features/poetry:2": {}`,
+				`"postCreateCommand": "poetry install --no-interaction"`,
+			},
+		},
+		{
+			name:     "Language image",
+			lang:     models.Language{Name: "typescript", BuildTool: "pnpm", Runtime: models.Runtime{Name: "node", Version: "22"}, CIImageTag: "node:22-alpine"},
+			expected: []string{`"image": "mcr.microsoft.com/devcontainers/typescript-node:22"`, `"ghcr.io/devcontainers-extra/features/pnpm:2"`},
+			absent:   []string{"postCreateCommand"},
+		},
+		{
+			name: "Feature for a version without an image",
+			lang: models.Language{Name: "kotlin", BuildTool: "gradle", Runtime: models.Runtime{Name: "jvm", Version: "22"}, CIImageTag: "eclipse-temurin:22-jdk-alpine"},
+			expected: []string{
+				`"image": "mcr.microsoft.com/devcontainers/base:ubuntu"`,
+				`"ghcr.io/devcontainers/features/java:1": {` + "\n" + `      "installGradle": "true",` + "\n" + `      "jdkDistro": "tem",` + "\n" + `      "version": "22"`,
+			},
+		},
+		{
+			name:     "Build tool feature on a devcontainer image",
+			lang:     models.Language{Name: "java", BuildTool: "maven", Runtime: models.Runtime{Name: "jvm", Version: "21"}, CIImageTag: "eclipse-temurin:21-jdk-alpine"},
+			expected: []string{`"image": "mcr.microsoft.com/devcontainers/java:21"`, `"installMaven": "true"`, `"version": "none"`},
+		},
+		{
+			name:     "Build tool installed with pipx",
+			lang:     models.Language{Name: "python", BuildTool: "uv", Runtime: models.Runtime{Name: "python", Version: "3.13"}, CIImageTag: "python:3.13-slim"},
+			commands: &models.Commands{Install: "uv sync --frozen"},
+			expected: []string{`"postCreateCommand": "pipx install uv && uv sync --frozen"`},
+			absent:   []string{"features"},
+		},
+		{
+			name:     "Runtime without devcontainer support runs in the CI image",
+			lang:     models.Language{Name: "elixir", BuildTool: "mix", Runtime: models.Runtime{Name: "otp", Version: "27"}, CIImageTag: "elixir:1.17-otp-27-alpine"},
+			commands: &models.Commands{Install: "mix deps.get"},
+			expected: []string{`"image": "elixir:1.17-otp-27-alpine"`, `"postCreateCommand": "mix deps.get"`},
+			absent:   []string{"features"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Devcontainer("app", &models.TechStack{Language: tt.lang, Commands: tt.commands}, catalog.Default())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(config, expected) {
+					t.Errorf("Expected devcontainer.json to contain %q, got:\n%s", expected, config)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(config, absent) {
+					t.Errorf("Expected devcontainer.json not to contain %q, got:\n%s", absent, config)
+				}
+			}
+		})
+	}
+
+	if _, err := Devcontainer("app", &models.TechStack{}, catalog.Default()); err == nil {
+		t.Error("Expected an error without a language")
+	}
+}
+
+func TestDevcontainerCatalog(t *testing.T) {
+	// A catalog without python 3.12 devcontainer tags falls back to the feature
+	c, err := catalog.Parse([]byte(`{"images": {"mcr.microsoft.com/devcontainers/python": {"versions": ["3.13"]}}}`))
+	if err != nil {
+		t.Fatalf("Failed to parse catalog: %v", err)
+	}
+	lang := models.Language{Name: "python", BuildTool: "pip", Runtime: models.Runtime{Name: "python", Version: "3.12"}, CIImageTag: "python:3.12-slim"}
+
+	config, err := Devcontainer("app", &models.TechStack{Language: lang}, c)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(config, "mcr.microsoft.com/devcontainers/python") || !strings.Contains(config, `"ghcr.io/devcontainers/features/python:1"`) {
+		t.Errorf("Expected the python feature rather than a devcontainer image, got:\n%s", config)
+	}
+}
@@ -0,0 +1,362 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+// artifact is what the build stage hands to the runtime stage
+type artifact int
+
+const (
+	artifactApp    artifact = iota // The /app directory, run by the language runtime
+	artifactBinary                 // A static binary at /out/app, run as /app/app
+	artifactJar                    // An executable jar at /out/app.jar
+	artifactDotnet                 // dotnet publish output in /out
+)
+
+// dockerRecipe describes how a build tool builds an image
+type dockerRecipe struct {
+	// Manifests and lockfiles are copied before the source, so the dependency
+	// layer is only rebuilt when they change. Missing files are skipped.
+	Manifests    []string
+	Caches       []string // Package manager caches mounted while installing and building
+	Setup        []string // Instructions preparing the build stage, e.g. installing the build tool
+	Env          []string // Build stage environment
+	InstallFlags string   // Appended to the detected install command, e.g. to skip dev dependencies
+	Build        string   // Replaces the detected build command; %s is the build target
+	SkipBuild    bool     // The detected build command packages a distribution the image does not need
+	Artifact     artifact
+
+	// ProjectInstallFlags install the project itself once its source is copied,
+	// for tools that skip it while only the manifests are present
+	ProjectInstallFlags string
+
+	// Runtime stage for app artifacts: directories copied besides /app, the
+	// environment, setup instructions and a prefix stripped from the run command
+	RuntimeCopy  []string
+	RuntimeEnv   []string
+	RuntimeSetup []string
+	RunPrefix    string
+}
+
+// pythonEnv installs Python dependencies into a virtualenv that is copied into the runtime stage
+var pythonEnv = []string{"VIRTUAL_ENV=/opt/venv", "PATH=/opt/venv/bin:$PATH"}
+
+// dockerRecipes holds the recipes per build tool. Build tools without one get a
+// plain two-stage build that copies /app into the runtime image.
+var dockerRecipes = map[string]dockerRecipe{
+	"npm": {
+		Manifests: []string{"package.json", "package-lock.json", "npm-shrinkwrap.json"},
+		Caches:    []string{"/root/.npm"},
+	},
+	"yarn": {
+		Manifests:    []string{"package.json", "yarn.lock", ".yarnrc.yml", ".yarn/releases"},
+		Caches:       []string{"/usr/local/share/.cache/yarn"},
+		Setup:        []string{"RUN corepack enable"},
+		RuntimeSetup: []string{"RUN corepack enable"},
+	},
+	"pnpm": {
+		Manifests:    []string{"package.json", "pnpm-lock.yaml", "pnpm-workspace.yaml", ".npmrc"},
+		Caches:       []string{"/root/.local/share/pnpm/store"},
+		Setup:        []string{"RUN corepack enable"},
+		RuntimeSetup: []string{"RUN corepack enable"},
+	},
+	"bun": {
+		Manifests: []string{"package.json", "bun.lock", "bun.lockb", "bunfig.toml"},
+		Caches:    []string{"/root/.bun/install/cache"},
+	},
+	"pip": {
+		Manifests:   []string{"requirements.txt"},
+		Caches:      []string{"/root/.cache/pip"},
+		Setup:       []string{"RUN python -m venv /opt/venv"},
+		Env:         pythonEnv,
+		SkipBuild:   true,
+		RuntimeCopy: []string{"/opt/venv"},
+		RuntimeEnv:  pythonEnv,
+	},
+	"poetry": {
+		Manifests:           []string{"pyproject.toml", "poetry.lock"},
+		Caches:              []string{"/root/.cache/pypoetry"},
+		Setup:               []string{"RUN pip install --no-cache-dir poetry && python -m venv /opt/venv"},
+		Env:                 pythonEnv,
+		InstallFlags:        "--no-root --only main",
+		ProjectInstallFlags: "--only main",
+		SkipBuild:           true,
+		RuntimeCopy:         []string{"/opt/venv"},
+		RuntimeEnv:          pythonEnv,
+		RunPrefix:           "poetry run ",
+	},
+	"uv": {
+		Manifests:           []string{"pyproject.toml", "uv.lock"},
+		Caches:              []string{"/root/.cache/uv"},
+		Setup:               []string{"RUN pip install --no-cache-dir uv"},
+		Env:                 []string{"UV_PROJECT_ENVIRONMENT=/opt/venv", "UV_LINK_MODE=copy"},
+		InstallFlags:        "--no-dev --no-install-project",
+		ProjectInstallFlags: "--no-dev",
+		SkipBuild:           true,
+		RuntimeCopy:         []string{"/opt/venv"},
+		RuntimeEnv:          pythonEnv,
+		RunPrefix:           "uv run ",
+	},
+	"bundle": {
+		Manifests:   []string{"Gemfile", "Gemfile.lock"},
+		Caches:      []string{"/usr/local/bundle/cache"},
+		Env:         []string{"BUNDLE_WITHOUT=development:test"},
+		RuntimeCopy: []string{"/usr/local/bundle"},
+		RuntimeEnv:  []string{"BUNDLE_WITHOUT=development:test"},
+	},
+	"composer": {
+		Manifests:    []string{"composer.json", "composer.lock"},
+		Caches:       []string{"/root/.composer/cache"},
+		Setup:        []string{"COPY --from=composer:2 /usr/bin/composer /usr/bin/composer"},
+		InstallFlags: "--no-dev --no-scripts --prefer-dist",
+	},
+	"go": {
+		Manifests: []string{"go.mod", "go.sum"},
+		Caches:    []string{"/go/pkg/mod", "/root/.cache/go-build"},
+		Build:     `CGO_ENABLED=0 go build -trimpath -ldflags="-s -w" -o /out/app %s`,
+		Artifact:  artifactBinary,
+	},
+	"cargo": {
+		// cargo fetch needs the source tree, so there are no manifests to copy ahead of it
+		Caches:   []string{"/usr/local/cargo/registry", "/app/target"},
+		Build:    "cargo build --release && mkdir -p /out && cp target/release/%s /out/app",
+		Artifact: artifactBinary,
+	},
+	"maven": {
+		Manifests: []string{"pom.xml", "mvnw", ".mvn"},
+		Caches:    []string{"/root/.m2"},
+		Build:     "%s -B package -DskipTests && mkdir -p /out && cp target/*.jar /out/app.jar",
+		Artifact:  artifactJar,
+	},
+	"gradle": {
+		Manifests: []string{"settings.gradle", "settings.gradle.kts", "build.gradle", "build.gradle.kts", "gradle.properties", "gradlew", "gradle"},
+		Caches:    []string{"/root/.gradle"},
+		Build:     "%s build -x test && mkdir -p /out && cp $(ls build/libs/*.jar | grep -v -- '-plain.jar$' | head -n 1) /out/app.jar",
+		Artifact:  artifactJar,
+	},
+	"dotnet": {
+		Manifests: []string{"*.sln", "*.slnx", "*.csproj", "*/*.csproj", "*/*/*.csproj", "Directory.Build.props", "Directory.Packages.props", "global.json", "NuGet.config"},
+		Caches:    []string{"/root/.nuget/packages"},
+		Build:     "dotnet publish %s -c Release -o /out",
+		Artifact:  artifactDotnet,
+	},
+}
+
+// Dockerfile renders a multi-stage Dockerfile for a detected stack. The build stage
+// runs the detected install and build commands in the CI image; the runtime stage
+// copies the result into the runtime image.
+func Dockerfile(path string, ts *models.TechStack) (string, error) {
+	lang := ts.Language
+	if lang.Name == "" || lang.CIImageTag == "" {
+		return "", fmt.Errorf("no language detected to build an image for")
+	}
+	var commands models.Commands
+	if ts.Commands != nil {
+		commands = *ts.Commands
+	}
+
+	recipe := dockerRecipes[lang.BuildTool]
+	install := commands.Install
+	if install != "" && recipe.InstallFlags != "" {
+		install += " " + recipe.InstallFlags
+	}
+	build := commands.Build
+	if recipe.SkipBuild {
+		build = ""
+	}
+
+	var entrypoint []string
+	switch recipe.Artifact {
+	case artifactBinary:
+		target, err := binaryTarget(path, lang.BuildTool, commands.Run)
+		if err != nil {
+			return "", err
+		}
+		build = fmt.Sprintf(recipe.Build, target)
+		entrypoint = []string{"/app/app"}
+	case artifactJar:
+		// The detected build command starts with the build tool or its wrapper
+		tool := strings.Fields(commands.Build)
+		if len(tool) == 0 {
+			return "", fmt.Errorf("no %s build command detected", lang.BuildTool)
+		}
+		build = fmt.Sprintf(recipe.Build, tool[0])
+		entrypoint = []string{"java", "-jar", "/app/app.jar"}
+	case artifactDotnet:
+		project, err := dotnetProject(path, commands.Run)
+		if err != nil {
+			return "", err
+		}
+		build = fmt.Sprintf(recipe.Build, project)
+		entrypoint = []string{"dotnet", strings.TrimSuffix(filepath.Base(project), ".csproj") + ".dll"}
+	}
+
+	runtimeImage := lang.RuntimeImageTag
+	if runtimeImage == "" {
+		runtimeImage = lang.CIImageTag
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by stackradar generate dockerfile\n\n")
+
+	fmt.Fprintf(&sb, "FROM %s AS build\n", lang.CIImageTag)
+	sb.WriteString("WORKDIR /app\n")
+	writeLines(&sb, recipe.Setup)
+	if len(recipe.Env) > 0 {
+		fmt.Fprintf(&sb, "ENV %s\n", strings.Join(recipe.Env, " "))
+	}
+
+	manifests := existingFiles(path, recipe.Manifests)
+	if len(manifests) > 0 && install != "" {
+		writeCopies(&sb, path, manifests)
+		writeRun(&sb, recipe.Caches, install)
+		sb.WriteString("COPY . .\n")
+		if recipe.ProjectInstallFlags != "" {
+			writeRun(&sb, recipe.Caches, commands.Install+" "+recipe.ProjectInstallFlags)
+		}
+	} else {
+		sb.WriteString("COPY . .\n")
+		if install != "" {
+			writeRun(&sb, recipe.Caches, install)
+		}
+	}
+	if build != "" {
+		writeRun(&sb, recipe.Caches, build)
+	}
+
+	fmt.Fprintf(&sb, "\nFROM %s\n", runtimeImage)
+	sb.WriteString("WORKDIR /app\n")
+	switch recipe.Artifact {
+	case artifactBinary:
+		sb.WriteString("COPY --from=build /out/app /app/app\n")
+	case artifactJar:
+		sb.WriteString("COPY --from=build /out/app.jar /app/app.jar\n")
+	case artifactDotnet:
+		sb.WriteString("COPY --from=build /out /app\n")
+	default:
+		writeLines(&sb, recipe.RuntimeSetup)
+		for _, dir := range recipe.RuntimeCopy {
+			fmt.Fprintf(&sb, "COPY --from=build %s %s\n", dir, dir)
+		}
+		if len(recipe.RuntimeEnv) > 0 {
+			fmt.Fprintf(&sb, "ENV %s\n", strings.Join(recipe.RuntimeEnv, " "))
+		}
+		sb.WriteString("COPY --from=build /app /app\n")
+	}
+
+	if entrypoint != nil {
+		fmt.Fprintf(&sb, "ENTRYPOINT %s\n", execForm(entrypoint))
+	} else if run := strings.TrimPrefix(commands.Run, recipe.RunPrefix); run != "" && runtimeImage == lang.CIImageTag {
+		// A dedicated runtime image, such as php-fpm, starts its own server
+		if strings.ContainsAny(run, `"'$&|;<>`+"`") {
+			fmt.Fprintf(&sb, "CMD %s\n", run)
+		} else {
+			fmt.Fprintf(&sb, "CMD %s\n", execForm(strings.Fields(run)))
+		}
+	}
+	return sb.String(), nil
+}
+
+// writeCopies copies files into the build stage at their relative paths. Files at
+// the root share one COPY; nested files and directories need their own.
+func writeCopies(sb *strings.Builder, path string, files []string) {
+	var root []string
+	for _, file := range files {
+		info, err := os.Stat(filepath.Join(path, file))
+		switch {
+		case err == nil && info.IsDir():
+			fmt.Fprintf(sb, "COPY %s %s/\n", file, file)
+		case strings.Contains(file, "/"):
+			fmt.Fprintf(sb, "COPY %s %s/\n", file, file[:strings.LastIndex(file, "/")])
+		default:
+			root = append(root, file)
+		}
+	}
+	if len(root) > 0 {
+		fmt.Fprintf(sb, "COPY %s ./\n", strings.Join(root, " "))
+	}
+}
+
+// writeRun writes a RUN instruction with the package manager caches mounted
+func writeRun(sb *strings.Builder, caches []string, command string) {
+	sb.WriteString("RUN ")
+	for _, cache := range caches {
+		fmt.Fprintf(sb, "--mount=type=cache,target=%s ", cache)
+	}
+	sb.WriteString(command + "\n")
+}
+
+// writeLines writes instructions one per line
+func writeLines(sb *strings.Builder, lines []string) {
+	for _, line := range lines {
+		sb.WriteString(line + "\n")
+	}
+}
+
+// execForm renders arguments as a JSON array, the exec form of CMD and ENTRYPOINT
+func execForm(args []string) string {
+	data, _ := json.Marshal(args)
+	return strings.ReplaceAll(string(data), `","`, `", "`)
+}
+
+// binaryTarget finds what to build for tools producing a single binary: the Go
+// package the run command starts, or the Cargo package name
+func binaryTarget(path, buildTool, run string) (string, error) {
+	if buildTool == "go" {
+		if target, ok := strings.CutPrefix(run, "go run "); ok {
+			return target, nil
+		}
+		return ".", nil
+	}
+
+	content, _ := os.ReadFile(filepath.Join(path, "Cargo.toml"))
+	inPackage := false
+	name := regexp.MustCompile(`^name\s*=\s*"([^"]+)"`)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			inPackage = line == "[package]"
+			continue
+		}
+		if match := name.FindStringSubmatch(line); inPackage && match != nil {
+			return match[1], nil
+		}
+	}
+	return "", fmt.Errorf("no [package] name in Cargo.toml to pick the binary from")
+}
+
+// dotnetProject finds the project to publish: the one the run command starts, or
+// the only project at the root
+func dotnetProject(path, run string) (string, error) {
+	if project, ok := strings.CutPrefix(run, "dotnet run --project "); ok {
+		return project, nil
+	}
+	if projects, _ := filepath.Glob(filepath.Join(path, "*.csproj")); len(projects) == 1 {
+		return filepath.Base(projects[0]), nil
+	}
+	return "", fmt.Errorf("no single executable .NET project to publish")
+}
+
+// existingFiles lists the files and directories of a project matching the given
+// patterns, relative to the project root
+func existingFiles(path string, patterns []string) []string {
+	var files []string
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(filepath.Join(path, pattern))
+		for _, match := range matches {
+			if rel, err := filepath.Rel(path, match); err == nil {
+				files = append(files, filepath.ToSlash(rel))
+			}
+		}
+	}
+	return files
+}
@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+func TestDockerfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		stack    models.TechStack
+		expected []string
+		absent   []string
+	}{
+		{
+			name:  "npm with lockfile",
+			files: map[string]string{"package.json": "{}", "package-lock.json": "{}"},
+			stack: models.TechStack{
+				Language: models.Language{Name: "typescript", BuildTool: "npm", CIImageTag: "node:20-alpine", RuntimeImageTag: "node:20-alpine"},
+				Commands: &models.Commands{Install: "npm ci", Build: "npm run build", Run: "npm start"},
+			},
+			expected: []string{
+				"FROM node:20-alpine AS build\n",
+				"COPY package.json package-lock.json ./\nRUN --mount=type=cache,target=/root/.npm npm ci\nCOPY . .\n",
+				"RUN --mount=type=cache,target=/root/.npm npm run build\n",
+				"COPY --from=build /app /app\n",
+				`CMD ["npm", "start"]`,
+			},
+		},
+		{
+			name:  "Go binary on distroless",
+			files: map[string]string{"go.mod": "module example.com/app\n", "go.sum": ""},
+			stack: models.TechStack{
+				Language: models.Language{Name: "go", BuildTool: "go", CIImageTag: "golang:1.22-alpine", RuntimeImageTag: "gcr.io/distroless/static"},
+				Commands: &models.Commands{Install: "go mod download", Build: "go build ./...", Run: "go run ./cmd/server"},
+			},
+			expected: []string{
+				"COPY go.mod go.sum ./\n",
+				"-o /out/app ./cmd/server\n",
+				"FROM gcr.io/distroless/static\nWORKDIR /app\nCOPY --from=build /out/app /app/app\n",
+				`ENTRYPOINT ["/app/app"]`,
+			},
+		},
+		{
+			name:  "Rust binary on distroless",
+			files: map[string]string{"Cargo.toml": "[package]\nname = \"server\"\n", "Cargo.lock": ""},
+			stack: models.TechStack{
+				Language: models.Language{Name: "rust", BuildTool: "cargo", CIImageTag: "rust:1.75-alpine", RuntimeImageTag: "gcr.io/distroless/static"},
+				Commands: &models.Commands{Install: "cargo fetch", Build: "cargo build --release"},
+			},
+			expected: []string{
+				"cp target/release/server /out/app\n",
+				"COPY --from=build /out/app /app/app\n",
+				`ENTRYPOINT ["/app/app"]`,
+			},
+		},
+		{
+			name:  "Maven jar on a JRE",
+			files: map[string]string{"pom.xml": "<project/>", "mvnw": "", ".mvn/wrapper/maven-wrapper.properties": ""},
+			stack: models.TechStack{
+				Language: models.Language{Name: "java", BuildTool: "maven", CIImageTag: "eclipse-temurin:21-jdk-alpine", RuntimeImageTag: "eclipse-temurin:21-jre-alpine"},
+				Commands: &models.Commands{Install: "./mvnw -B dependency:go-offline", Build: "./mvnw -B package -DskipTests"},
+			},
+			expected: []string{
+				"COPY .mvn .mvn/\nCOPY pom.xml mvnw ./\n",
+				"--mount=type=cache,target=/root/.m2 ./mvnw -B package -DskipTests",
+				"FROM eclipse-temurin:21-jre-alpine\n",
+				`ENTRYPOINT ["java", "-jar", "/app/app.jar"]`,
+			},
+		},
+		{
+			name:  "uv installs the project after the source",
+			files: map[string]string{"pyproject.toml": "", "uv.lock": ""},
+			stack: models.TechStack{
+				Language: models.Language{Name: "python", BuildTool: "uv", CIImageTag: "python:3.12-slim", RuntimeImageTag: "python:3.12-slim"},
+				Commands: &models.Commands{Install: "uv sync --frozen", Build: "uv build", Run: "uv run serve"},
+			},
+			expected: []string{
+				"uv sync --frozen --no-dev --no-install-project\nCOPY . .\n",
+				"uv sync --frozen --no-dev\n",
+				"COPY --from=build /opt/venv /opt/venv\n",
+				`CMD ["serve"]`,
+			},
+			absent: []string{"uv build"},
+		},
+		{
+			name:  "Install after the source without manifests",
+			files: map[string]string{"setup.py": ""},
+			stack: models.TechStack{
+				Language: models.Language{Name: "python", BuildTool: "pip", CIImageTag: "python:3.12-slim", RuntimeImageTag: "python:3.12-slim"},
+				Commands: &models.Commands{Install: "pip install ."},
+			},
+			expected: []string{"COPY . .\nRUN --mount=type=cache,target=/root/.cache/pip pip install .\n"},
+		},
+		{
+			name:  "PHP-FPM keeps its own command",
+			files: map[string]string{"composer.json": "{}"},
+			stack: models.TechStack{
+				Language: models.Language{Name: "php", BuildTool: "composer", CIImageTag: "php:8.3-cli-alpine", RuntimeImageTag: "php:8.3-fpm-alpine"},
+				Commands: &models.Commands{Install: "composer install --no-interaction", Run: "php artisan serve"},
+			},
+			expected: []string{
+				"COPY --from=composer:2 /usr/bin/composer /usr/bin/composer\n",
+				"composer install --no-interaction --no-dev --no-scripts --prefer-dist\n",
+				"FROM php:8.3-fpm-alpine\n",
+			},
+			absent: []string{"CMD"},
+		},
+		{
+			name:  "Build tool without a recipe",
+			files: map[string]string{"mix.exs": ""},
+			stack: models.TechStack{
+				Language: models.Language{Name: "elixir", BuildTool: "mix", CIImageTag: "elixir:1.16-alpine", RuntimeImageTag: "elixir:1.16-alpine"},
+				Commands: &models.Commands{Install: "mix deps.get", Build: "mix compile", Run: "mix phx.server"},
+			},
+			expected: []string{
+				"COPY . .\nRUN mix deps.get\nRUN mix compile\n",
+				`CMD ["mix", "phx.server"]`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tmpDir)
+
+			for filename, content := range tt.files {
+				file := filepath.Join(tmpDir, filename)
+				if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+					t.Fatalf("Failed to create directory: %v", err)
+				}
+				if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			}
+
+			dockerfile, err := Dockerfile(tmpDir, &tt.stack)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(dockerfile, expected) {
+					t.Errorf("Expected Dockerfile to contain %q, got:\n%s", expected, dockerfile)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(dockerfile, absent) {
+					t.Errorf("Expected Dockerfile not to contain %q, got:\n%s", absent, dockerfile)
+				}
+			}
+		})
+	}
+}
+
+func TestDockerfileErrors(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tests := []struct {
+		name  string
+		stack models.TechStack
+	}{
+		{"Infrastructure only", models.TechStack{IaC: []models.IaCTool{{Name: "terraform"}}}},
+		{"Cargo workspace without package", models.TechStack{Language: models.Language{Name: "rust", BuildTool: "cargo", CIImageTag: "rust:1.75-alpine"}}},
+		{".NET library", models.TechStack{Language: models.Language{Name: "csharp", BuildTool: "dotnet", CIImageTag: "mcr.microsoft.com/dotnet/sdk:8.0-alpine"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Dockerfile(tmpDir, &tt.stack); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package lifecycle
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/stack-radar/stackradar/pkg/version"
+)
+
+//go:embed lifecycle.json
+var embedded []byte
+
+// dateLayout is the format of lifecycle dates
+const dateLayout = "2006-01-02"
+
+// Lifecycle holds release, active support and end-of-life dates per runtime
+// release cycle, keyed by runtime name as in the language configuration
+type Lifecycle struct {
+	Updated  string             `json:"updated"`
+	Runtimes map[string][]Cycle `json:"runtimes"`
+}
+
+// Cycle is a release line of a runtime, e.g. Python 3.12 or Node.js 20
+type Cycle struct {
+	Cycle   string `json:"cycle"`
+	Release string `json:"release"`
+	LTS     string `json:"lts,omitempty"`     // Start of long-term support, for runtimes with LTS releases
+	Support string `json:"support,omitempty"` // End of active support
+	EOL     string `json:"eol,omitempty"`     // Empty until an end-of-life date is announced
+}
+
+// Default returns the lifecycle data embedded in the binary
+func Default() *Lifecycle {
+	l, err := Parse(embedded)
+	if err != nil {
+		panic(fmt.Sprintf("embedded lifecycle data: %v", err))
+	}
+	return l
+}
+
+// Load reads lifecycle data from a JSON file, e.g. one refreshed on a machine with network access
+func Load(path string) (*Lifecycle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lifecycle data: %w", err)
+	}
+	l, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lifecycle data %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// Parse decodes lifecycle data from JSON
+func Parse(data []byte) (*Lifecycle, error) {
+	var l Lifecycle
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, err
+	}
+	for runtime, cycles := range l.Runtimes {
+		for _, cycle := range cycles {
+			for _, date := range []string{cycle.Release, cycle.LTS, cycle.Support, cycle.EOL} {
+				if _, err := time.Parse(dateLayout, date); date != "" && err != nil {
+					return nil, fmt.Errorf("%s %s: invalid date %q", runtime, cycle.Cycle, date)
+				}
+			}
+		}
+	}
+	return &l, nil
+}
+
+// Lookup finds the release cycle a runtime version belongs to, e.g. 3.12 for
+// Python 3.12.4. JVM versions such as 1.8 are matched by their major version.
+func (l *Lifecycle) Lookup(runtime, version string) (Cycle, bool) {
+	if l == nil {
+		return Cycle{}, false
+	}
+	if runtime == "jvm" {
+		version = strings.TrimPrefix(version, "1.")
+	}
+
+	var found Cycle
+	for _, cycle := range l.Runtimes[runtime] {
+		if (version == cycle.Cycle || strings.HasPrefix(version, cycle.Cycle+".")) && len(cycle.Cycle) > len(found.Cycle) {
+			found = cycle
+		}
+	}
+	return found, found.Cycle != ""
+}
+
+// Covers reports whether there is lifecycle data for a runtime
+func (l *Lifecycle) Covers(runtime string) bool {
+	return l != nil && len(l.Runtimes[runtime]) > 0
+}
+
+// Upgrades returns the newest supported cycle and the newest supported LTS cycle
+// of a runtime, each only when it is newer than the given cycle
+func (l *Lifecycle) Upgrades(runtime, current string, now time.Time) (latest, lts string) {
+	if l == nil {
+		return "", ""
+	}
+	for _, cycle := range l.Runtimes[runtime] {
+		if !reached(cycle.Release, now) || cycle.IsEOL(now) || version.Compare(cycle.Cycle, current) <= 0 {
+			continue
+		}
+		if latest == "" || version.Compare(cycle.Cycle, latest) > 0 {
+			latest = cycle.Cycle
+		}
+		if reached(cycle.LTS, now) && (lts == "" || version.Compare(cycle.Cycle, lts) > 0) {
+			lts = cycle.Cycle
+		}
+	}
+	return latest, lts
+}
+
+// IsEOL reports whether the cycle reached end of life at the given time
+func (c Cycle) IsEOL(now time.Time) bool {
+	return reached(c.EOL, now)
+}
+
+// IsLTS reports whether the cycle is a long-term support release at the given time
+func (c Cycle) IsLTS(now time.Time) bool {
+	return reached(c.LTS, now)
+}
+
+// reached reports whether a date is set and not after now
+func reached(date string, now time.Time) bool {
+	if date == "" {
+		return false
+	}
+	t, err := time.Parse(dateLayout, date)
+	return err == nil && !now.Before(t)
+}
@@ -0,0 +1,124 @@
+package lifecycle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLookup(t *testing.T) {
+	l := Default()
+
+	tests := []struct {
+		runtime  string
+		version  string
+		expected string
+		found    bool
+	}{
+		{"python", "3.12.4", "3.12", true},
+		{"python", "3.1", "", false}, // not a prefix of 3.10
+		{"node", "20.11.1", "20", true},
+		{"jvm", "1.8", "8", true},
+		{"jvm", "21", "21", true},
+		{"dotnet", "8.0.100", "8.0", true},
+		{"go", "1.22.3", "1.22", true},
+		{"rust", "1.75.0", "1.75", true},
+		{"rust", "1.7", "1.7", true},
+		{"gcc", "13.2", "13", true},
+		{"r", "4.3.2", "4.3", true},
+		{"swift", "5.9", "", false}, // no published support policy
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.runtime+"/"+tt.version, func(t *testing.T) {
+			cycle, found := l.Lookup(tt.runtime, tt.version)
+			if found != tt.found || cycle.Cycle != tt.expected {
+				t.Errorf("Lookup(%q, %q) = %q, %v, expected %q, %v", tt.runtime, tt.version, cycle.Cycle, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
+
+func TestIsEOL(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		cycle    Cycle
+		expected bool
+	}{
+		{"Past end of life", Cycle{Cycle: "3.7", EOL: "2023-06-27"}, true},
+		{"On end of life date", Cycle{Cycle: "x", EOL: "2025-01-01"}, true},
+		{"Supported", Cycle{Cycle: "3.12", EOL: "2028-10-31"}, false},
+		{"No end of life announced", Cycle{Cycle: "2"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cycle.IsEOL(now); got != tt.expected {
+				t.Errorf("IsEOL() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	valid := filepath.Join(tmpDir, "lifecycle.json")
+	if err := os.WriteFile(valid, []byte(`{"runtimes": {"python": [{"cycle": "3.15", "release": "2026-10-01", "eol": "2031-10-31"}]}}`), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	invalid := filepath.Join(tmpDir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"runtimes": {"python": [{"cycle": "3.15", "release": "October 2026"}]}}`), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	l, err := Load(valid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cycle, ok := l.Lookup("python", "3.15.0"); !ok || cycle.EOL != "2031-10-31" {
+		t.Errorf("Expected refreshed cycle 3.15, got %+v", cycle)
+	}
+
+	if _, err := Load(invalid); err == nil {
+		t.Error("Expected error for an invalid date")
+	}
+}
+
+func TestUpgrades(t *testing.T) {
+	l := &Lifecycle{Runtimes: map[string][]Cycle{
+		"node": {
+			{Cycle: "18", Release: "2022-04-19", LTS: "2022-10-25", EOL: "2025-04-30"},
+			{Cycle: "20", Release: "2023-04-18", LTS: "2023-10-24", EOL: "2026-04-30"},
+			{Cycle: "21", Release: "2023-10-17", EOL: "2024-06-01"},
+			{Cycle: "22", Release: "2024-04-24", LTS: "2024-10-29", EOL: "2027-04-30"},
+		},
+	}}
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		current string
+		latest  string
+		lts     string
+	}{
+		{"18", "22", "20"}, // 22 is not LTS yet, 21 reached end of life
+		{"20", "22", ""},
+		{"22", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current, func(t *testing.T) {
+			latest, lts := l.Upgrades("node", tt.current, now)
+			if latest != tt.latest || lts != tt.lts {
+				t.Errorf("Upgrades(%q) = %q, %q, expected %q, %q", tt.current, latest, lts, tt.latest, tt.lts)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package lockfile
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the lock file read from the root of a repository
+const FileName = "stackradar.lock"
+
+const header = "# Generated by stackradar lock. Refresh with `stackradar lock` instead of editing.\n"
+
+// Lock records the content digests of generated image tags for reproducible builds
+type Lock struct {
+	Images map[string]string `yaml:"images"` // Image tag to sha256 digest
+}
+
+// New creates an empty lock
+func New() *Lock {
+	return &Lock{Images: make(map[string]string)}
+}
+
+// Load reads a lock file. A missing file returns an error wrapping os.ErrNotExist.
+func Load(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+	l := New()
+	if err := yaml.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file %s: %w", path, err)
+	}
+	if l.Images == nil {
+		l.Images = make(map[string]string)
+	}
+	return l, nil
+}
+
+// Save writes the lock file with images sorted by tag
+func (l *Lock) Save(path string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to marshal lock file: %w", err)
+	}
+	if err := os.WriteFile(path, append([]byte(header), data...), 0644); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
+
+// Refresh records the digest of each image from the first resolver that knows it.
+// Entries for images no longer generated are dropped; an image no resolver knows
+// keeps its previous digest and is reported in the returned errors.
+func (l *Lock) Refresh(images []string, resolvers ...Resolver) []error {
+	previous := l.Images
+	l.Images = make(map[string]string)
+
+	var errs []error
+	for _, image := range images {
+		key := Normalize(image)
+		digest, err := resolve(image, resolvers)
+		if err != nil {
+			errs = append(errs, err)
+			digest = previous[key]
+		}
+		if digest != "" {
+			l.Images[key] = digest
+		}
+	}
+	return errs
+}
+
+// resolve asks each resolver in turn for the digest of an image
+func resolve(image string, resolvers []Resolver) (string, error) {
+	err := fmt.Errorf("no digest source for %s", image)
+	for _, resolver := range resolvers {
+		var digest string
+		if digest, err = resolver.Digest(image); err == nil {
+			return digest, nil
+		}
+	}
+	return "", err
+}
+
+// Pin returns the image pinned to its locked digest, e.g. python:3.12-slim@sha256:...
+func (l *Lock) Pin(image string) (string, bool) {
+	digest, ok := l.Images[Normalize(image)]
+	if !ok {
+		return image, false
+	}
+	return image + "@" + digest, true
+}
+
+// Apply pins the language CI and runtime image tags and the IaC image tags of a detected stack. Tags without
+// a locked digest are left as is and reported as warnings.
+func (l *Lock) Apply(ts *models.TechStack) {
+	pin := func(image string) string {
+		if image == "" {
+			return image
+		}
+		pinned, ok := l.Pin(image)
+		if !ok {
+			ts.Warnings = append(ts.Warnings, fmt.Sprintf("%s has no digest for %s; run stackradar lock to refresh it", FileName, image))
+		}
+		return pinned
+	}
+
+	// Languages without a runtime image reuse the CI image; warn about it only once
+	sameImage := ts.Language.RuntimeImageTag == ts.Language.CIImageTag
+	ts.Language.CIImageTag = pin(ts.Language.CIImageTag)
+	if sameImage {
+		ts.Language.RuntimeImageTag = ts.Language.CIImageTag
+	} else {
+		ts.Language.RuntimeImageTag = pin(ts.Language.RuntimeImageTag)
+	}
+	for i := range ts.IaC {
+		ts.IaC[i].CIImageTag = pin(ts.IaC[i].CIImageTag)
+	}
+}
+
+// Images lists the image tags of a detected stack that can be locked
+func Images(ts *models.TechStack) []string {
+	var images []string
+	for _, image := range []string{ts.Language.CIImageTag, ts.Language.RuntimeImageTag} {
+		if image != "" && !slices.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+	for _, tool := range ts.IaC {
+		images = append(images, tool.CIImageTag)
+	}
+	return images
+}
+
+// Normalize drops any digest and the implicit Docker Hub registry from an image
+// reference, so docker.io/library/python:3.12-slim and python:3.12-slim match
+func Normalize(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	image = strings.TrimPrefix(image, "docker.io/")
+	image = strings.TrimPrefix(image, "index.docker.io/")
+	return strings.TrimPrefix(image, "library/")
+}
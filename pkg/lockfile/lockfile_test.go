@@ -0,0 +1,120 @@
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+const (
+	pythonDigest    = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	terraformDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	file := filepath.Join(tmpDir, FileName)
+	if _, err := Load(file); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Expected os.ErrNotExist for a missing lock file, got %v", err)
+	}
+
+	lock := New()
+	lock.Images["python:3.12-slim"] = pythonDigest
+	if err := lock.Save(file); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loaded, err := Load(file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Images, lock.Images) {
+		t.Errorf("Expected %v, got %v", lock.Images, loaded.Images)
+	}
+}
+
+func TestApply(t *testing.T) {
+	lock := New()
+	lock.Images["python:3.12-slim"] = pythonDigest
+
+	ts := &models.TechStack{
+		Language: models.Language{Name: "python", CIImageTag: "python:3.12-slim", RuntimeImageTag: "python:3.12-slim"},
+		IaC:      []models.IaCTool{{Name: "terraform", Version: "1.9.8", CIImageTag: "hashicorp/terraform:1.9.8"}},
+	}
+	lock.Apply(ts)
+
+	if expected := "python:3.12-slim@" + pythonDigest; ts.Language.CIImageTag != expected {
+		t.Errorf("Expected %q, got %q", expected, ts.Language.CIImageTag)
+	}
+	if ts.Language.RuntimeImageTag != ts.Language.CIImageTag {
+		t.Errorf("Expected runtime image to share the CI image pin, got %q", ts.Language.RuntimeImageTag)
+	}
+	if ts.IaC[0].CIImageTag != "hashicorp/terraform:1.9.8" {
+		t.Errorf("Expected unlocked tag to stay unpinned, got %q", ts.IaC[0].CIImageTag)
+	}
+	if len(ts.Warnings) != 1 {
+		t.Errorf("Expected a warning for the unlocked tag, got %v", ts.Warnings)
+	}
+}
+
+// staticResolver resolves digests from a fixed map
+type staticResolver map[string]string
+
+func (r staticResolver) Digest(image string) (string, error) {
+	if digest, ok := r[image]; ok {
+		return digest, nil
+	}
+	return "", fmt.Errorf("unknown image %s", image)
+}
+
+func TestRefresh(t *testing.T) {
+	lock := New()
+	lock.Images["python:3.11-slim"] = pythonDigest
+	lock.Images["hashicorp/terraform:1.9.8"] = terraformDigest
+
+	errs := lock.Refresh(
+		[]string{"python:3.12-slim", "hashicorp/terraform:1.9.8"},
+		staticResolver{"python:3.12-slim": pythonDigest},
+	)
+
+	expected := map[string]string{
+		"python:3.12-slim":          pythonDigest,
+		"hashicorp/terraform:1.9.8": terraformDigest, // unresolved, previous digest kept
+	}
+	if !reflect.DeepEqual(lock.Images, expected) {
+		t.Errorf("Expected %v, got %v", expected, lock.Images)
+	}
+	if len(errs) != 1 {
+		t.Errorf("Expected one error for the unresolved image, got %v", errs)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"python:3.12-slim", "python:3.12-slim"},
+		{"docker.io/library/python:3.12-slim", "python:3.12-slim"},
+		{"docker.io/oven/bun:1.1@" + pythonDigest, "oven/bun:1.1"},
+		{"ghcr.io/opentofu/opentofu:1.8.1", "ghcr.io/opentofu/opentofu:1.8.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Normalize(tt.input); got != tt.expected {
+				t.Errorf("Normalize(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
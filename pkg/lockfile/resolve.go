@@ -0,0 +1,163 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Resolver looks up the content digest of an image tag
+type Resolver interface {
+	Digest(image string) (string, error)
+}
+
+// manifestMediaTypes are accepted from registries, preferring multi-platform indexes
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// RegistryResolver reads digests from a registry speaking the Docker Registry
+// HTTP API v2, such as a local pull-through mirror
+type RegistryResolver struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewRegistryResolver creates a resolver for the registry at url, e.g. http://localhost:5000
+func NewRegistryResolver(url string) *RegistryResolver {
+	return &RegistryResolver{
+		URL:    strings.TrimSuffix(url, "/"),
+		Client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Digest returns the Docker-Content-Digest the registry reports for an image tag.
+// Mirrors store Docker Hub official images under library/, and images from other
+// registries under their path without the host.
+func (r *RegistryResolver) Digest(image string) (string, error) {
+	repository, tag := splitImage(Normalize(image))
+	if tag == "" {
+		tag = "latest"
+	}
+	if first, rest, ok := strings.Cut(repository, "/"); ok && strings.ContainsAny(first, ".:") {
+		repository = rest
+	} else if !ok {
+		repository = "library/" + repository
+	}
+
+	req, err := http.NewRequest(http.MethodHead, fmt.Sprintf("%s/v2/%s/manifests/%s", r.URL, repository, tag), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query registry for %s: %w", image, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned %s for %s", resp.Status, image)
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("registry returned no digest for %s", image)
+	}
+	return digest, nil
+}
+
+// ManifestResolver reads digests from saved `docker manifest inspect -v` output
+type ManifestResolver struct {
+	digests map[string]string
+}
+
+// manifestEntry is one element of `docker manifest inspect -v` output
+type manifestEntry struct {
+	Ref        string `json:"Ref"`
+	Descriptor struct {
+		Digest   string `json:"digest"`
+		Platform *struct {
+			Architecture string `json:"architecture"`
+			OS           string `json:"os"`
+			Variant      string `json:"variant"`
+		} `json:"platform"`
+	} `json:"Descriptor"`
+}
+
+// LoadManifests reads `docker manifest inspect -v` output from files or directories
+// of .json files. Multi-platform images record the digest for platform, e.g. linux/amd64.
+func LoadManifests(platform string, paths ...string) (*ManifestResolver, error) {
+	r := &ManifestResolver{digests: make(map[string]string)}
+	for _, path := range paths {
+		files := []string{path}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			files, _ = filepath.Glob(filepath.Join(path, "*.json"))
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read manifest: %w", err)
+			}
+			if err := r.add(data, platform); err != nil {
+				return nil, fmt.Errorf("failed to parse manifest %s: %w", file, err)
+			}
+		}
+	}
+	return r, nil
+}
+
+// add records the digests of a single-platform object or a multi-platform array
+func (r *ManifestResolver) add(data []byte, platform string) error {
+	var entries []manifestEntry
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return err
+		}
+	} else {
+		var entry manifestEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, entry := range entries {
+		if entry.Ref == "" || entry.Descriptor.Digest == "" {
+			continue
+		}
+		if p := entry.Descriptor.Platform; p != nil && len(entries) > 1 {
+			name := p.OS + "/" + p.Architecture
+			if p.Variant != "" && strings.Count(platform, "/") == 2 {
+				name += "/" + p.Variant
+			}
+			if name != platform {
+				continue
+			}
+		}
+		r.digests[Normalize(entry.Ref)] = entry.Descriptor.Digest
+	}
+	return nil
+}
+
+// Digest returns the recorded digest for an image tag
+func (r *ManifestResolver) Digest(image string) (string, error) {
+	if digest, ok := r.digests[Normalize(image)]; ok {
+		return digest, nil
+	}
+	return "", fmt.Errorf("no manifest recorded for %s", image)
+}
+
+// splitImage splits an image reference into repository and tag
+func splitImage(image string) (repository, tag string) {
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		return image[:idx], image[idx+1:]
+	}
+	return image, ""
+}
@@ -0,0 +1,91 @@
+package lockfile
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistryResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/library/python/manifests/3.12-slim":
+			w.Header().Set("Docker-Content-Digest", pythonDigest)
+		case "/v2/hashicorp/terraform/manifests/1.9.8", "/v2/dotnet/sdk/manifests/8.0-alpine":
+			w.Header().Set("Docker-Content-Digest", terraformDigest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewRegistryResolver(server.URL + "/")
+	tests := []struct {
+		image    string
+		expected string
+		err      bool
+	}{
+		{"python:3.12-slim", pythonDigest, false},
+		{"hashicorp/terraform:1.9.8", terraformDigest, false},
+		{"mcr.microsoft.com/dotnet/sdk:8.0-alpine", terraformDigest, false},
+		{"python:2.7-slim", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			digest, err := resolver.Digest(tt.image)
+			if tt.err != (err != nil) {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if digest != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, digest)
+			}
+		})
+	}
+}
+
+func TestLoadManifests(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		// Multi-platform image
+		"python.json": `[
+  {"Ref": "docker.io/library/python:3.12-slim@sha256:aaaa", "Descriptor": {"digest": "` + pythonDigest + `", "platform": {"architecture": "amd64", "os": "linux"}}},
+  {"Ref": "docker.io/library/python:3.12-slim@sha256:bbbb", "Descriptor": {"digest": "sha256:arm", "platform": {"architecture": "arm64", "os": "linux", "variant": "v8"}}}
+]`,
+		// Single-platform image
+		"terraform.json": `{"Ref": "docker.io/hashicorp/terraform:1.9.8", "Descriptor": {"digest": "` + terraformDigest + `", "platform": {"architecture": "amd64", "os": "linux"}}}`,
+	}
+	for filename, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	resolver, err := LoadManifests("linux/amd64", tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for image, expected := range map[string]string{
+		"python:3.12-slim":          pythonDigest,
+		"hashicorp/terraform:1.9.8": terraformDigest,
+	} {
+		if digest, err := resolver.Digest(image); err != nil || digest != expected {
+			t.Errorf("Digest(%q) = %q, %v, expected %q", image, digest, err, expected)
+		}
+	}
+
+	arm, err := LoadManifests("linux/arm64", filepath.Join(tmpDir, "python.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if digest, _ := arm.Digest("python:3.12-slim"); digest != "sha256:arm" {
+		t.Errorf("Expected arm64 digest, got %q", digest)
+	}
+}
@@ -5,25 +5,213 @@ import (
 	"strings"
 )
 
+// Runtime represents the runtime that executes a language, e.g. JVM 21 or Node 20
+type Runtime struct {
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version" yaml:"version"`
+	EOL     *bool  `json:"eol,omitempty" yaml:"eol,omitempty"` // Set when lifecycle data covers the version
+	EOLDate string `json:"eol_date,omitempty" yaml:"eol_date,omitempty"`
+
+	// Upgrade suggestions, set when a newer supported release exists
+	Latest    string `json:"latest,omitempty" yaml:"latest,omitempty"`
+	LatestLTS string `json:"latest_lts,omitempty" yaml:"latest_lts,omitempty"`
+}
+
+// Toolchain represents a compiler, SDK or build system version explicitly pinned
+// by the project, e.g. the go.mod toolchain directive or cmake_minimum_required
+type Toolchain struct {
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version" yaml:"version"`
+}
+
 // Language represents programming language information
 type Language struct {
+	Name            string     `json:"name" yaml:"name"`
+	LanguageVersion string     `json:"language_version" yaml:"language_version"`
+	Runtime         Runtime    `json:"runtime" yaml:"runtime"`
+	Toolchain       *Toolchain `json:"toolchain,omitempty" yaml:"toolchain,omitempty"`
+	BuildTool       string     `json:"build_tool" yaml:"build_tool"`
+	PackageManager  string     `json:"package_manager,omitempty" yaml:"package_manager,omitempty"`
+	CIImageTag      string     `json:"ci_image_tag" yaml:"ci_image_tag"`
+	RuntimeImageTag string     `json:"runtime_image_tag" yaml:"runtime_image_tag"`             // Slim image that runs the built application
+	AppType         string     `json:"app_type,omitempty" yaml:"app_type,omitempty"`           // web, worker or cli, when detectable
+	ImageVariant    string     `json:"image_variant,omitempty" yaml:"image_variant,omitempty"` // glibc or musl, when dependencies force a choice
+	ImageReason     string     `json:"image_reason,omitempty" yaml:"image_reason,omitempty"`
+}
+
+// IaCTool represents an infrastructure-as-code tool used by the repository
+type IaCTool struct {
 	Name       string `json:"name" yaml:"name"`
 	Version    string `json:"version" yaml:"version"`
-	BuildTool  string `json:"build_tool" yaml:"build_tool"`
+	Language   string `json:"language,omitempty" yaml:"language,omitempty"` // Program language, e.g. for Pulumi
 	CIImageTag string `json:"ci_image_tag" yaml:"ci_image_tag"`
 }
 
+// Upgrade represents an upgrade candidate for the runtime of a project in a repository
+type Upgrade struct {
+	Project   string   `json:"project" yaml:"project"` // Relative to the repository root
+	Language  string   `json:"language" yaml:"language"`
+	Runtime   string   `json:"runtime" yaml:"runtime"`
+	Version   string   `json:"version" yaml:"version"`
+	Latest    string   `json:"latest,omitempty" yaml:"latest,omitempty"`
+	LatestLTS string   `json:"latest_lts,omitempty" yaml:"latest_lts,omitempty"`
+	EOL       bool     `json:"eol" yaml:"eol"`
+	Files     []string `json:"files,omitempty" yaml:"files,omitempty"` // Files pinning the current version
+}
+
+// VersionPin is a runtime version pinned by a file besides the manifest, e.g. .nvmrc,
+// a Dockerfile base image or a CI setup step
+type VersionPin struct {
+	Runtime string `json:"runtime" yaml:"runtime"`
+	Version string `json:"version" yaml:"version"`
+	File    string `json:"file" yaml:"file"`                         // Relative to the project path
+	Source  string `json:"source,omitempty" yaml:"source,omitempty"` // What in the file pins it, e.g. an image or setup action input
+}
+
+// Drift represents a runtime whose pinned versions disagree within a project
+type Drift struct {
+	Project  string       `json:"project" yaml:"project"` // Relative to the repository root
+	Runtime  string       `json:"runtime" yaml:"runtime"`
+	Detected string       `json:"detected,omitempty" yaml:"detected,omitempty"` // Version detected for the runtime of the project
+	Pins     []VersionPin `json:"pins" yaml:"pins"`
+}
+
+// Framework represents an application framework the project is built on, e.g. Django or Spring Boot
+type Framework struct {
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
+
+// Service represents a backing service such as a database or message broker the project needs
+type Service struct {
+	Name    string `json:"name" yaml:"name"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	Image   string `json:"image,omitempty" yaml:"image,omitempty"` // Image declared in a Compose file
+}
+
+// Commands represents suggested commands for working with the project in CI
+type Commands struct {
+	Install string `json:"install,omitempty" yaml:"install,omitempty"`
+	Build   string `json:"build,omitempty" yaml:"build,omitempty"`
+	Test    string `json:"test,omitempty" yaml:"test,omitempty"`
+	Lint    string `json:"lint,omitempty" yaml:"lint,omitempty"`
+	Run     string `json:"run,omitempty" yaml:"run,omitempty"`
+}
+
+// IsEmpty reports whether no command could be suggested
+func (c Commands) IsEmpty() bool {
+	return c == Commands{}
+}
+
 // TechStack represents the complete tech stack information
 type TechStack struct {
-	Language Language `json:"language" yaml:"language"`
+	Language       Language    `json:"language" yaml:"language"`
+	Frameworks     []Framework `json:"frameworks,omitempty" yaml:"frameworks,omitempty"`
+	TestFrameworks []string    `json:"test_frameworks,omitempty" yaml:"test_frameworks,omitempty"`
+	BuildCommand   string      `json:"build_command,omitempty" yaml:"build_command,omitempty"` // Same as Commands.Build, kept for existing consumers
+	TestCommand    string      `json:"test_command,omitempty" yaml:"test_command,omitempty"`   // Same as Commands.Test, kept for existing consumers
+	Commands       *Commands   `json:"commands,omitempty" yaml:"commands,omitempty"`
+	Services       []Service   `json:"services,omitempty" yaml:"services,omitempty"`
+	IaC            []IaCTool   `json:"iac,omitempty" yaml:"iac,omitempty"`
+	Warnings       []string    `json:"warnings,omitempty" yaml:"warnings,omitempty"` // e.g. image tags missing from the catalog
 }
 
 // ToEnv converts TechStack to environment variable format
 func (ts *TechStack) ToEnv() string {
+	var toolchain Toolchain
+	if ts.Language.Toolchain != nil {
+		toolchain = *ts.Language.Toolchain
+	}
+
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("LANGUAGE_NAME=%s\n", ts.Language.Name))
-	sb.WriteString(fmt.Sprintf("LANGUAGE_VERSION=%s\n", ts.Language.Version))
+	sb.WriteString(fmt.Sprintf("LANGUAGE_VERSION=%s\n", ts.Language.LanguageVersion))
+	sb.WriteString(fmt.Sprintf("RUNTIME_NAME=%s\n", ts.Language.Runtime.Name))
+	sb.WriteString(fmt.Sprintf("RUNTIME_VERSION=%s\n", ts.Language.Runtime.Version))
+	sb.WriteString(fmt.Sprintf("TOOLCHAIN_NAME=%s\n", toolchain.Name))
+	sb.WriteString(fmt.Sprintf("TOOLCHAIN_VERSION=%s\n", toolchain.Version))
 	sb.WriteString(fmt.Sprintf("BUILD_TOOL=%s\n", ts.Language.BuildTool))
+	sb.WriteString(fmt.Sprintf("PACKAGE_MANAGER=%s\n", ts.Language.PackageManager))
 	sb.WriteString(fmt.Sprintf("CI_IMAGE_TAG=%s\n", ts.Language.CIImageTag))
+	if ts.Language.RuntimeImageTag != "" {
+		sb.WriteString(fmt.Sprintf("RUNTIME_IMAGE_TAG=%s\n", ts.Language.RuntimeImageTag))
+	}
+	if ts.Language.AppType != "" {
+		sb.WriteString(fmt.Sprintf("APP_TYPE=%s\n", ts.Language.AppType))
+	}
+	if eol := ts.Language.Runtime.EOL; eol != nil {
+		sb.WriteString(fmt.Sprintf("RUNTIME_EOL=%t\n", *eol))
+		sb.WriteString(fmt.Sprintf("RUNTIME_EOL_DATE=%s\n", ts.Language.Runtime.EOLDate))
+	}
+	if ts.Language.ImageVariant != "" {
+		sb.WriteString(fmt.Sprintf("IMAGE_VARIANT=%s\n", ts.Language.ImageVariant))
+		sb.WriteString(fmt.Sprintf("IMAGE_REASON=%s\n", shellQuote(ts.Language.ImageReason)))
+	}
+
+	if len(ts.Frameworks) > 0 {
+		names := make([]string, 0, len(ts.Frameworks))
+		for _, framework := range ts.Frameworks {
+			names = append(names, framework.Name)
+		}
+		sb.WriteString(fmt.Sprintf("FRAMEWORKS=%s\n", strings.Join(names, ",")))
+		for _, framework := range ts.Frameworks {
+			sb.WriteString(fmt.Sprintf("%s_VERSION=%s\n", envName(framework.Name), framework.Version))
+		}
+	}
+
+	if len(ts.TestFrameworks) > 0 {
+		sb.WriteString(fmt.Sprintf("TEST_FRAMEWORKS=%s\n", strings.Join(ts.TestFrameworks, ",")))
+	}
+	if ts.Commands != nil {
+		for _, command := range []struct{ name, value string }{
+			{"INSTALL", ts.Commands.Install},
+			{"BUILD", ts.Commands.Build},
+			{"TEST", ts.Commands.Test},
+			{"LINT", ts.Commands.Lint},
+			{"RUN", ts.Commands.Run},
+		} {
+			if command.value != "" {
+				sb.WriteString(fmt.Sprintf("%s_COMMAND=%s\n", command.name, shellQuote(command.value)))
+			}
+		}
+	}
+
+	if len(ts.Services) > 0 {
+		names := make([]string, 0, len(ts.Services))
+		for _, service := range ts.Services {
+			names = append(names, service.Name)
+		}
+		sb.WriteString(fmt.Sprintf("SERVICES=%s\n", strings.Join(names, ",")))
+		for _, service := range ts.Services {
+			prefix := envName(service.Name)
+			sb.WriteString(fmt.Sprintf("%s_VERSION=%s\n", prefix, service.Version))
+			if service.Image != "" {
+				sb.WriteString(fmt.Sprintf("%s_IMAGE=%s\n", prefix, service.Image))
+			}
+		}
+	}
+
+	if len(ts.IaC) > 0 {
+		names := make([]string, 0, len(ts.IaC))
+		for _, tool := range ts.IaC {
+			names = append(names, tool.Name)
+		}
+		sb.WriteString(fmt.Sprintf("IAC_TOOLS=%s\n", strings.Join(names, ",")))
+		for _, tool := range ts.IaC {
+			prefix := envName(tool.Name)
+			sb.WriteString(fmt.Sprintf("%s_VERSION=%s\n", prefix, tool.Version))
+			sb.WriteString(fmt.Sprintf("%s_IMAGE_TAG=%s\n", prefix, tool.CIImageTag))
+		}
+	}
 	return sb.String()
 }
+
+// envName converts a tool name into an environment variable prefix
+func envName(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// shellQuote single-quotes a value so commands survive being sourced by a shell
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
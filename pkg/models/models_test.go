@@ -7,17 +7,21 @@ import (
 
 func TestLanguageStruct(t *testing.T) {
 	lang := Language{
-		Name:       "python",
-		Version:    "3.12",
-		BuildTool:  "poetry",
-		CIImageTag: "python:3.12-slim",
+		Name:            "python",
+		LanguageVersion: "3.12",
+		Runtime:         Runtime{Name: "python", Version: "3.12"},
+		BuildTool:       "poetry",
+		CIImageTag:      "python:3.12-slim",
 	}
 
 	if lang.Name != "python" {
 		t.Errorf("Expected Name to be 'python', got %q", lang.Name)
 	}
-	if lang.Version != "3.12" {
-		t.Errorf("Expected Version to be '3.12', got %q", lang.Version)
+	if lang.LanguageVersion != "3.12" {
+		t.Errorf("Expected LanguageVersion to be '3.12', got %q", lang.LanguageVersion)
+	}
+	if lang.Runtime.Version != "3.12" {
+		t.Errorf("Expected Runtime.Version to be '3.12', got %q", lang.Runtime.Version)
 	}
 	if lang.BuildTool != "poetry" {
 		t.Errorf("Expected BuildTool to be 'poetry', got %q", lang.BuildTool)
@@ -30,10 +34,12 @@ func TestLanguageStruct(t *testing.T) {
 func TestToEnv(t *testing.T) {
 	ts := TechStack{
 		Language: Language{
-			Name:       "go",
-			Version:    "1.24",
-			BuildTool:  "go",
-			CIImageTag: "golang:1.24-alpine",
+			Name:            "go",
+			LanguageVersion: "1.24",
+			Runtime:         Runtime{Name: "go", Version: "1.24"},
+			Toolchain:       &Toolchain{Name: "go", Version: "1.24.2"},
+			BuildTool:       "go",
+			CIImageTag:      "golang:1.24-alpine",
 		},
 	}
 
@@ -41,15 +47,20 @@ func TestToEnv(t *testing.T) {
 
 	// Check format
 	lines := strings.Split(strings.TrimSpace(env), "\n")
-	if len(lines) != 4 {
-		t.Errorf("Expected 4 lines, got %d", len(lines))
+	if len(lines) != 9 {
+		t.Errorf("Expected 9 lines, got %d", len(lines))
 	}
 
 	// Check each line
 	expectedLines := map[string]bool{
 		"LANGUAGE_NAME=go":                true,
 		"LANGUAGE_VERSION=1.24":           true,
+		"RUNTIME_NAME=go":                 true,
+		"RUNTIME_VERSION=1.24":            true,
+		"TOOLCHAIN_NAME=go":               true,
+		"TOOLCHAIN_VERSION=1.24.2":        true,
 		"BUILD_TOOL=go":                   true,
+		"PACKAGE_MANAGER=":                true,
 		"CI_IMAGE_TAG=golang:1.24-alpine": true,
 	}
 
@@ -63,10 +74,10 @@ func TestToEnv(t *testing.T) {
 func TestToEnvWithEmptyValues(t *testing.T) {
 	ts := TechStack{
 		Language: Language{
-			Name:       "unknown",
-			Version:    "",
-			BuildTool:  "",
-			CIImageTag: "",
+			Name:            "unknown",
+			LanguageVersion: "",
+			BuildTool:       "",
+			CIImageTag:      "",
 		},
 	}
 
@@ -74,8 +85,8 @@ func TestToEnvWithEmptyValues(t *testing.T) {
 
 	// Should still generate all lines, just with empty values
 	lines := strings.Split(strings.TrimSpace(env), "\n")
-	if len(lines) != 4 {
-		t.Errorf("Expected 4 lines even with empty values, got %d", len(lines))
+	if len(lines) != 9 {
+		t.Errorf("Expected 9 lines even with empty values, got %d", len(lines))
 	}
 
 	// Verify keys exist
@@ -85,3 +96,166 @@ func TestToEnvWithEmptyValues(t *testing.T) {
 		}
 	}
 }
+
+func TestToEnvWithIaC(t *testing.T) {
+	ts := TechStack{
+		IaC: []IaCTool{
+			{Name: "terraform", Version: "1.7.5", CIImageTag: "hashicorp/terraform:1.7.5"},
+			{Name: "helm", Version: "3.15.4", CIImageTag: "alpine/helm:3.15.4"},
+		},
+	}
+
+	env := ts.ToEnv()
+
+	for _, expected := range []string{
+		"IAC_TOOLS=terraform,helm\n",
+		"TERRAFORM_VERSION=1.7.5\n",
+		"TERRAFORM_IMAGE_TAG=hashicorp/terraform:1.7.5\n",
+		"HELM_IMAGE_TAG=alpine/helm:3.15.4\n",
+	} {
+		if !strings.Contains(env, expected) {
+			t.Errorf("Expected env output to contain %q, got:\n%s", expected, env)
+		}
+	}
+}
+
+func TestToEnvWithFrameworks(t *testing.T) {
+	ts := TechStack{
+		Language: Language{Name: "typescript"},
+		Frameworks: []Framework{
+			{Name: "nextjs", Version: "14.1.4"},
+			{Name: "spring-boot", Version: "3.2.4"},
+		},
+	}
+
+	env := ts.ToEnv()
+
+	for _, expected := range []string{
+		"FRAMEWORKS=nextjs,spring-boot\n",
+		"NEXTJS_VERSION=14.1.4\n",
+		"SPRING_BOOT_VERSION=3.2.4\n",
+	} {
+		if !strings.Contains(env, expected) {
+			t.Errorf("Expected env output to contain %q, got:\n%s", expected, env)
+		}
+	}
+}
+
+func TestToEnvWithCommands(t *testing.T) {
+	ts := TechStack{
+		Language:       Language{Name: "python"},
+		TestFrameworks: []string{"pytest", "tox"},
+		Commands: &Commands{
+			Install: "poetry install --no-interaction",
+			Build:   "poetry build",
+			Test:    `julia --project -e 'using Pkg; Pkg.test()'`,
+		},
+	}
+
+	env := ts.ToEnv()
+
+	for _, expected := range []string{
+		"TEST_FRAMEWORKS=pytest,tox\n",
+		"INSTALL_COMMAND='poetry install --no-interaction'\n",
+		"BUILD_COMMAND='poetry build'\n",
+		`TEST_COMMAND='julia --project -e '\''using Pkg; Pkg.test()'\'''` + "\n",
+	} {
+		if !strings.Contains(env, expected) {
+			t.Errorf("Expected env output to contain %q, got:\n%s", expected, env)
+		}
+	}
+	if strings.Contains(env, "LINT_COMMAND") {
+		t.Errorf("Expected no LINT_COMMAND without a lint command, got:\n%s", env)
+	}
+}
+
+func TestToEnvWithServices(t *testing.T) {
+	ts := TechStack{
+		Language: Language{Name: "python"},
+		Services: []Service{
+			{Name: "postgres", Version: "15", Image: "postgres:15-alpine"},
+			{Name: "redis"},
+		},
+	}
+
+	env := ts.ToEnv()
+
+	for _, expected := range []string{
+		"SERVICES=postgres,redis\n",
+		"POSTGRES_VERSION=15\n",
+		"POSTGRES_IMAGE=postgres:15-alpine\n",
+		"REDIS_VERSION=\n",
+	} {
+		if !strings.Contains(env, expected) {
+			t.Errorf("Expected env output to contain %q, got:\n%s", expected, env)
+		}
+	}
+	if strings.Contains(env, "REDIS_IMAGE") {
+		t.Errorf("Expected no REDIS_IMAGE without a Compose image, got:\n%s", env)
+	}
+}
+
+func TestToEnvWithImageVariant(t *testing.T) {
+	ts := TechStack{
+		Language: Language{
+			Name:         "node",
+			CIImageTag:   "node:20-bookworm-slim",
+			ImageVariant: "glibc",
+			ImageReason:  "native dependencies need glibc: sharp",
+		},
+	}
+
+	env := ts.ToEnv()
+
+	for _, expected := range []string{
+		"IMAGE_VARIANT=glibc\n",
+		"IMAGE_REASON='native dependencies need glibc: sharp'\n",
+	} {
+		if !strings.Contains(env, expected) {
+			t.Errorf("Expected env output to contain %q, got:\n%s", expected, env)
+		}
+	}
+}
+
+func TestToEnvWithEOL(t *testing.T) {
+	eol := true
+	ts := TechStack{
+		Language: Language{
+			Name:    "python",
+			Runtime: Runtime{Name: "python", Version: "3.7.17", EOL: &eol, EOLDate: "2023-06-27"},
+		},
+	}
+
+	env := ts.ToEnv()
+
+	for _, expected := range []string{
+		"RUNTIME_EOL=true\n",
+		"RUNTIME_EOL_DATE=2023-06-27\n",
+	} {
+		if !strings.Contains(env, expected) {
+			t.Errorf("Expected env output to contain %q, got:\n%s", expected, env)
+		}
+	}
+}
+
+func TestToEnvWithRuntimeImage(t *testing.T) {
+	ts := TechStack{
+		Language: Language{
+			Name:            "java",
+			CIImageTag:      "eclipse-temurin:21-jdk-alpine",
+			RuntimeImageTag: "eclipse-temurin:21-jre-alpine",
+			AppType:         "web",
+		},
+	}
+
+	env := ts.ToEnv()
+
+	for _, expected := range []string{
+		"RUNTIME_IMAGE_TAG=eclipse-temurin:21-jre-alpine\n",
+		"APP_TYPE=web\n",
+	} {
+		if !strings.Contains(env, expected) {
+			t.Errorf("Expected env output to contain %q, got:\n%s", expected, env)
+		}
+	}
+}
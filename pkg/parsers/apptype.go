@@ -0,0 +1,138 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+// App types, in order of precedence when a project shows signs of several
+const (
+	AppTypeWeb    = "web"
+	AppTypeWorker = "worker"
+	AppTypeCLI    = "cli"
+)
+
+// webFrameworks serve HTTP. Frontend libraries such as React are left out: they
+// build static assets rather than run a server.
+var webFrameworks = []string{
+	"django", "fastapi", "flask",
+	"nextjs", "nuxt", "nestjs", "sveltekit", "express",
+	"rails", "sinatra", "hanami",
+	"laravel", "symfony",
+	"gin", "echo", "fiber", "chi",
+	"phoenix",
+	"spring-boot", "quarkus", "micronaut",
+	"aspnetcore",
+}
+
+// appTypeDependencies maps packages that mark a project as a web server, background
+// worker or command-line tool, per package ecosystem
+var appTypeDependencies = map[string]map[string]string{
+	"python": {
+		"celery":   AppTypeWorker,
+		"rq":       AppTypeWorker,
+		"dramatiq": AppTypeWorker,
+		"click":    AppTypeCLI,
+		"typer":    AppTypeCLI,
+	},
+	"node": {
+		"bullmq":    AppTypeWorker,
+		"bull":      AppTypeWorker,
+		"commander": AppTypeCLI,
+		"yargs":     AppTypeCLI,
+	},
+	"ruby": {
+		"sidekiq":  AppTypeWorker,
+		"resque":   AppTypeWorker,
+		"good_job": AppTypeWorker,
+		"thor":     AppTypeCLI,
+	},
+	"php": {
+		"laravel/horizon": AppTypeWorker,
+		"symfony/console": AppTypeCLI,
+	},
+	"go": {
+		"github.com/hibiken/asynq": AppTypeWorker,
+		"github.com/spf13/cobra":   AppTypeCLI,
+		"github.com/urfave/cli":    AppTypeCLI,
+	},
+	"elixir": {
+		"oban": AppTypeWorker,
+	},
+	"rust": {
+		"axum":      AppTypeWeb,
+		"actix-web": AppTypeWeb,
+		"rocket":    AppTypeWeb,
+		"warp":      AppTypeWeb,
+		"clap":      AppTypeCLI,
+	},
+}
+
+// DetectAppType classifies a project as a web server, background worker or
+// command-line tool from its frameworks and dependencies. A project showing
+// signs of several is classified by the first of web, worker and cli; an
+// empty string means the type could not be told.
+func DetectAppType(path, language string, frameworks []models.Framework) string {
+	found := make(map[string]bool)
+	for _, framework := range frameworks {
+		if slices.Contains(webFrameworks, framework.Name) {
+			found[AppTypeWeb] = true
+		}
+	}
+
+	ecosystem, deps := ecosystemDependencies(path, language)
+	for name, appType := range appTypeDependencies[ecosystem] {
+		if _, ok := deps[name]; ok {
+			found[appType] = true
+		}
+	}
+
+	if ecosystem == "node" && hasPackageBin(path) {
+		found[AppTypeCLI] = true
+	}
+	switch language {
+	case "java", "kotlin", "scala", "clojure":
+		if matchBuildFiles(path, regexp.MustCompile(`info\.picocli`), "pom.xml", "build.gradle.kts", "build.gradle", "build.sbt", "project.clj", "deps.edn") {
+			found[AppTypeCLI] = true
+		}
+	case "dotnet", "csharp":
+		if matchBuildFiles(path, regexp.MustCompile(`Sdk="Microsoft\.NET\.Sdk\.Worker"|Microsoft\.Extensions\.Hosting\.WindowsServices`), "*.csproj", "*/*.csproj") {
+			found[AppTypeWorker] = true
+		}
+		if matchBuildFiles(path, regexp.MustCompile(`System\.CommandLine|Spectre\.Console\.Cli`), "*.csproj", "*/*.csproj") {
+			found[AppTypeCLI] = true
+		}
+	}
+
+	for _, appType := range []string{AppTypeWeb, AppTypeWorker, AppTypeCLI} {
+		if found[appType] {
+			return appType
+		}
+	}
+	return ""
+}
+
+// hasPackageBin reports whether package.json installs executables through its "bin" field
+func hasPackageBin(path string) bool {
+	var pkg struct {
+		Bin json.RawMessage `json:"bin"`
+	}
+	content := readFile(filepath.Join(path, "package.json"))
+	return content != "" && json.Unmarshal([]byte(content), &pkg) == nil && len(pkg.Bin) > 0 && string(pkg.Bin) != "null"
+}
+
+// matchBuildFiles reports whether any build file matching the given patterns contains the marker
+func matchBuildFiles(path string, marker *regexp.Regexp, patterns ...string) bool {
+	for _, pattern := range patterns {
+		for _, file := range globFiles(path, pattern) {
+			if marker.MatchString(readFile(file)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+func TestDetectAppType(t *testing.T) {
+	tests := []struct {
+		name       string
+		language   string
+		files      map[string]string
+		frameworks []models.Framework
+		expected   string
+	}{
+		{
+			name:       "Web framework",
+			language:   "python",
+			files:      map[string]string{"requirements.txt": "fastapi==0.110.0\n"},
+			frameworks: []models.Framework{{Name: "fastapi", Version: "0.110.0"}},
+			expected:   AppTypeWeb,
+		},
+		{
+			name:       "Web wins over worker",
+			language:   "ruby",
+			files:      map[string]string{"Gemfile": "gem 'rails'\ngem 'sidekiq'\n"},
+			frameworks: []models.Framework{{Name: "rails"}},
+			expected:   AppTypeWeb,
+		},
+		{
+			name:     "Celery worker",
+			language: "python",
+			files:    map[string]string{"requirements.txt": "celery==5.3.6\nredis==5.0.1\n"},
+			expected: AppTypeWorker,
+		},
+		{
+			name:     "Cobra CLI",
+			language: "go",
+			files:    map[string]string{"go.mod": "module example.com/tool\n\ngo 1.22\n\nrequire github.com/spf13/cobra v1.8.0\n"},
+			expected: AppTypeCLI,
+		},
+		{
+			name:     "package.json bin",
+			language: "node",
+			files:    map[string]string{"package.json": `{"name": "tool", "bin": {"tool": "./cli.js"}}`},
+			expected: AppTypeCLI,
+		},
+		{
+			name:     "Axum server",
+			language: "rust",
+			files:    map[string]string{"Cargo.toml": "[package]\nname = \"api\"\n\n[dependencies]\naxum = \"0.7\"\nclap = \"4\"\n"},
+			expected: AppTypeWeb,
+		},
+		{
+			name:     ".NET worker SDK",
+			language: "csharp",
+			files:    map[string]string{"Jobs.csproj": `<Project Sdk="Microsoft.NET.Sdk.Worker"></Project>`},
+			expected: AppTypeWorker,
+		},
+		{
+			name:       "Frontend library is not a server",
+			language:   "typescript",
+			files:      map[string]string{"package.json": `{"dependencies": {"react": "^18.2.0"}}`},
+			frameworks: []models.Framework{{Name: "react", Version: "18.2.0"}},
+			expected:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			result := DetectAppType(tmpDir, tt.language, tt.frameworks)
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Clojure detection
+func detectClojureVersion(path string) string {
+	patterns := map[string]string{
+		"project.clj": `\[org\.clojure/clojure\s+"(\d+\.\d+)`,
+		"deps.edn":    `org\.clojure/clojure\s+\{:mvn/version\s+"(\d+\.\d+)`,
+	}
+	for _, file := range []string{"project.clj", "deps.edn"} {
+		if content := readFile(filepath.Join(path, file)); content != "" {
+			if match := regexp.MustCompile(patterns[file]).FindStringSubmatch(content); match != nil {
+				return match[1]
+			}
+		}
+	}
+	return ""
+}
+
+// detectClojureJVMVersion reads the JVM release from .java-version or javac options
+// in project.clj, deps.edn aliases and tools.build scripts
+func detectClojureJVMVersion(path string) string {
+	if content := readFile(filepath.Join(path, ".java-version")); content != "" {
+		return jdkMajorVersion(strings.TrimSpace(content))
+	}
+	re := regexp.MustCompile(`"(?:--release|-target)"\s+"(?:1\.)?(\d+)"`)
+	for _, file := range []string{"project.clj", "deps.edn", "build.clj"} {
+		if match := re.FindStringSubmatch(readFile(filepath.Join(path, file))); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+func detectClojureBuildTool(path string) string {
+	if fileExists(path, "project.clj") {
+		return "lein"
+	}
+	return "clojure"
+}
@@ -0,0 +1,63 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectClojureVersions(t *testing.T) {
+	tests := []struct {
+		name            string
+		files           map[string]string
+		expectedBuild   string
+		expectedVersion string
+		expectedJVM     string
+	}{
+		{
+			name: "Leiningen with javac options",
+			files: map[string]string{
+				"project.clj": `(defproject app "0.1.0" :dependencies [[org.clojure/clojure "1.11.1"]] :javac-options ["-target" "17" "-source" "17"])`,
+			},
+			expectedBuild:   "lein",
+			expectedVersion: "1.11",
+			expectedJVM:     "17",
+		},
+		{
+			name: "Clojure CLI with release alias",
+			files: map[string]string{
+				"deps.edn": `{:deps {org.clojure/clojure {:mvn/version "1.12.0"}} :aliases {:build {:javac-opts ["--release" "21"]}}}`,
+			},
+			expectedBuild:   "clojure",
+			expectedVersion: "1.12",
+			expectedJVM:     "21",
+		},
+		{
+			name: "Clojure CLI with .java-version",
+			files: map[string]string{
+				"deps.edn":      `{:deps {org.clojure/clojure {:mvn/version "1.11.2"}}}`,
+				".java-version": "11.0.22",
+			},
+			expectedBuild:   "clojure",
+			expectedVersion: "1.11",
+			expectedJVM:     "11",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			buildTool := DetectBuildTool(tmpDir, "clojure")
+			if buildTool != tt.expectedBuild {
+				t.Errorf("Expected build tool %q, got %q", tt.expectedBuild, buildTool)
+			}
+			if result := DetectVersion(tmpDir, "clojure", buildTool); result != tt.expectedVersion {
+				t.Errorf("Expected Clojure version %q, got %q", tt.expectedVersion, result)
+			}
+			if result := DetectRuntimeVersion(tmpDir, "clojure", buildTool); result != tt.expectedJVM {
+				t.Errorf("Expected JVM version %q, got %q", tt.expectedJVM, result)
+			}
+		})
+	}
+}
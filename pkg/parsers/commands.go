@@ -0,0 +1,637 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+// DetectCommands suggests install, build, test, lint and run commands for a project.
+// Entry points the project defines itself (package.json scripts, Makefile targets,
+// pyproject task runners) win over commands derived from the build tool.
+func DetectCommands(path, language, buildTool, packageManager string, testFrameworks []string) models.Commands {
+	scripts := projectScripts(path, buildTool)
+	pick := func(task, derived string) string {
+		if command, ok := scripts[task]; ok {
+			return command
+		}
+		return derived
+	}
+
+	return models.Commands{
+		Install: installCommand(path, buildTool, packageManager),
+		Build:   pick("build", buildCommand(path, buildTool)),
+		Test:    pick("test", testCommand(path, buildTool, testFrameworks)),
+		Lint:    pick("lint", lintCommand(path, language, buildTool)),
+		Run:     pick("run", runCommand(path, buildTool)),
+	}
+}
+
+// installCommand restores dependencies, honouring the lockfile where the tool supports it
+func installCommand(path, buildTool, packageManager string) string {
+	switch buildTool {
+	case "npm":
+		if fileExists(path, "package-lock.json") {
+			return "npm ci"
+		}
+		return "npm install"
+	case "yarn":
+		// Yarn Berry replaced --frozen-lockfile with --immutable
+		if fileExists(path, ".yarnrc.yml") {
+			return "yarn install --immutable"
+		}
+		return "yarn install --frozen-lockfile"
+	case "pnpm":
+		return "pnpm install --frozen-lockfile"
+	case "bun":
+		if fileExists(path, "bun.lockb") || fileExists(path, "bun.lock") {
+			return "bun install --frozen-lockfile"
+		}
+		return "bun install"
+	case "poetry":
+		return "poetry install --no-interaction"
+	case "pdm":
+		return "pdm install --frozen-lockfile"
+	case "uv":
+		if fileExists(path, "uv.lock") {
+			return "uv sync --frozen"
+		}
+		return "uv sync"
+	case "pipenv":
+		if fileExists(path, "Pipfile.lock") {
+			return "pipenv install --deploy"
+		}
+		return "pipenv install"
+	case "hatch":
+		return "hatch env create"
+	case "pip":
+		if fileExists(path, "requirements.txt") {
+			return "pip install -r requirements.txt"
+		}
+		if fileExists(path, "pyproject.toml") || fileExists(path, "setup.py") {
+			return "pip install ."
+		}
+	case "maven":
+		return mavenCommand(path) + " -B dependency:go-offline"
+	case "sbt":
+		return "sbt update"
+	case "go":
+		return "go mod download"
+	case "cargo":
+		if fileExists(path, "Cargo.lock") {
+			return "cargo fetch --locked"
+		}
+		return "cargo fetch"
+	case "bundle":
+		return "bundle install"
+	case "composer":
+		return "composer install --no-interaction"
+	case "dotnet":
+		return "dotnet restore"
+	case "swift":
+		return "swift package resolve"
+	case "mix":
+		return "mix deps.get"
+	case "rebar3":
+		return "rebar3 get-deps"
+	case "dart", "flutter":
+		return buildTool + " pub get"
+	case "deno":
+		return "deno install"
+	case "cmake", "meson":
+		switch packageManager {
+		case "conan":
+			return "conan install . --build=missing"
+		case "vcpkg":
+			return "vcpkg install"
+		}
+	case "stack", "cabal":
+		return buildTool + " build --only-dependencies"
+	case "lein":
+		return "lein deps"
+	case "clojure":
+		return "clojure -P"
+	case "dune", "opam":
+		return "opam install . --deps-only"
+	case "nimble":
+		return "nimble install -d"
+	case "shards":
+		if fileExists(path, "shard.lock") {
+			return "shards install --frozen"
+		}
+		return "shards install"
+	case "renv":
+		return `Rscript -e "renv::restore()"`
+	case "pak":
+		return `Rscript -e "pak::local_install_deps()"`
+	case "Pkg":
+		return `julia --project -e "using Pkg; Pkg.instantiate()"`
+	case "cpanm", "module-build":
+		return "cpanm --installdeps ."
+	case "carton":
+		return "carton install --deployment"
+	case "dzil":
+		return "dzil authordeps --missing | cpanm && dzil listdeps --missing | cpanm"
+	case "make":
+		if fileExists(path, "Makefile.PL") {
+			return "cpanm --installdeps ."
+		}
+	case "luarocks":
+		if rockspecs := globFiles(path, "*.rockspec"); len(rockspecs) > 0 {
+			return "luarocks install --only-deps " + filepath.Base(rockspecs[0])
+		}
+	}
+	return ""
+}
+
+// buildCommand builds the project with its build tool.
+// It returns an empty string when the ecosystem has no separate build step.
+func buildCommand(path, buildTool string) string {
+	switch buildTool {
+	case "maven":
+		return mavenCommand(path) + " -B package -DskipTests"
+	case "gradle":
+		return gradleCommand(path) + " build -x test"
+	case "sbt":
+		return "sbt compile"
+	case "poetry", "pdm", "uv", "hatch":
+		return buildTool + " build"
+	case "pip":
+		if fileExists(path, "pyproject.toml") {
+			return "python -m build"
+		}
+	case "go":
+		return "go build ./..."
+	case "cargo":
+		return "cargo build --release"
+	case "dotnet":
+		return "dotnet build -c Release"
+	case "swift":
+		return "swift build -c release"
+	case "mix":
+		return "mix compile"
+	case "rebar3":
+		return "rebar3 compile"
+	case "erlang.mk":
+		return "make"
+	case "make":
+		if fileExists(path, "Makefile.PL") {
+			return "perl Makefile.PL && make"
+		}
+		return "make"
+	case "cmake":
+		return "cmake -S . -B build && cmake --build build"
+	case "meson":
+		return "meson setup build && meson compile -C build"
+	case "stack", "cabal", "zig", "nimble", "shards":
+		return buildTool + " build"
+	case "dune":
+		return "dune build"
+	case "opam":
+		return "opam exec -- dune build"
+	case "lein":
+		return "lein uberjar"
+	case "dzil":
+		return "dzil build"
+	case "module-build":
+		return "perl Build.PL && ./Build"
+	}
+	return ""
+}
+
+// testCommand runs the project's tests, preferring the detected test framework
+// and falling back to the build tool's own test task
+func testCommand(path, buildTool string, testFrameworks []string) string {
+	switch buildTool {
+	case "maven":
+		return mavenCommand(path) + " -B test"
+	case "gradle":
+		return gradleCommand(path) + " test"
+	case "sbt":
+		return "sbt test"
+	case "npm", "yarn", "pnpm", "bun":
+		exec := nodeExecCommand(buildTool)
+		switch {
+		case slices.Contains(testFrameworks, "vitest"):
+			return exec + " vitest run"
+		case slices.Contains(testFrameworks, "jest"):
+			return exec + " jest"
+		case slices.Contains(testFrameworks, "mocha"):
+			return exec + " mocha"
+		case slices.Contains(testFrameworks, "playwright"):
+			return exec + " playwright test"
+		case buildTool == "bun":
+			return "bun test"
+		}
+	case "pip", "poetry", "pdm", "pipenv", "uv", "hatch":
+		prefix := pythonRunPrefix(buildTool)
+		switch {
+		case slices.Contains(testFrameworks, "pytest"):
+			return prefix + "pytest"
+		case slices.Contains(testFrameworks, "unittest"):
+			return prefix + "python -m unittest discover"
+		case slices.Contains(testFrameworks, "tox"):
+			return "tox"
+		case slices.Contains(testFrameworks, "nox"):
+			return "nox"
+		}
+	case "go":
+		return "go test ./..."
+	case "cargo":
+		return "cargo test"
+	case "bundle":
+		switch {
+		case slices.Contains(testFrameworks, "rspec"):
+			return "bundle exec rspec"
+		case slices.Contains(testFrameworks, "minitest"):
+			if _, ok := rubyDependencies(path)["rails"]; ok {
+				return "bundle exec rails test"
+			}
+			return "bundle exec rake test"
+		}
+	case "composer":
+		switch {
+		case slices.Contains(testFrameworks, "pest"):
+			return "vendor/bin/pest"
+		case slices.Contains(testFrameworks, "phpunit"):
+			return "vendor/bin/phpunit"
+		}
+	case "dotnet":
+		return "dotnet test"
+	case "swift":
+		return "swift test"
+	case "mix":
+		return "mix test"
+	case "rebar3":
+		return "rebar3 eunit"
+	case "erlang.mk":
+		return "make test"
+	case "make":
+		if fileExists(path, "Makefile.PL") {
+			return "perl Makefile.PL && make test"
+		}
+		return "make test"
+	case "dart", "flutter", "deno":
+		return buildTool + " test"
+	case "cmake":
+		return "ctest --test-dir build"
+	case "meson":
+		return "meson test -C build"
+	case "stack", "cabal", "nimble", "dune", "lein":
+		return buildTool + " test"
+	case "opam":
+		return "opam exec -- dune test"
+	case "clojure":
+		return "clojure -X:test"
+	case "zig":
+		return "zig build test"
+	case "shards":
+		return "crystal spec"
+	case "Pkg":
+		return `julia --project -e "using Pkg; Pkg.test()"`
+	case "cpanm":
+		return "prove -lr t"
+	case "carton":
+		return "carton exec prove -lr t"
+	case "dzil":
+		return "dzil test"
+	case "module-build":
+		return "perl Build.PL && ./Build test"
+	}
+	return ""
+}
+
+// lintCommand runs the linter the project is configured for, or the ecosystem's
+// built-in checker when the tool ships one
+func lintCommand(path, language, buildTool string) string {
+	switch language {
+	case "bash", "sh":
+		return "git ls-files '*.sh' | xargs shellcheck"
+	}
+
+	switch buildTool {
+	case "npm", "yarn", "pnpm", "bun":
+		deps := nodeDependencies(path)
+		if _, ok := deps["@biomejs/biome"]; ok || fileExists(path, "biome.json") {
+			return nodeExecCommand(buildTool) + " biome check ."
+		}
+		if _, ok := deps["eslint"]; ok {
+			return nodeExecCommand(buildTool) + " eslint ."
+		}
+	case "pip", "poetry", "pdm", "pipenv", "uv", "hatch":
+		deps := pythonDependencies(path)
+		if _, ok := deps["ruff"]; ok || fileExists(path, "ruff.toml") ||
+			strings.Contains(readFile(filepath.Join(path, "pyproject.toml")), "[tool.ruff") {
+			return pythonRunPrefix(buildTool) + "ruff check ."
+		}
+		if _, ok := deps["flake8"]; ok || fileExists(path, ".flake8") {
+			return pythonRunPrefix(buildTool) + "flake8"
+		}
+	case "maven":
+		pom := readFile(filepath.Join(path, "pom.xml"))
+		if strings.Contains(pom, "spotless-maven-plugin") {
+			return mavenCommand(path) + " -B spotless:check"
+		}
+		if strings.Contains(pom, "maven-checkstyle-plugin") {
+			return mavenCommand(path) + " -B checkstyle:check"
+		}
+	case "gradle":
+		build := readFile(filepath.Join(path, "build.gradle.kts")) + readFile(filepath.Join(path, "build.gradle"))
+		switch {
+		case strings.Contains(build, "com.diffplug.spotless"):
+			return gradleCommand(path) + " spotlessCheck"
+		case strings.Contains(build, "io.gitlab.arturbosch.detekt"):
+			return gradleCommand(path) + " detekt"
+		case strings.Contains(build, "org.jlleitschuh.gradle.ktlint"):
+			return gradleCommand(path) + " ktlintCheck"
+		}
+	case "go":
+		for _, config := range []string{".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"} {
+			if fileExists(path, config) {
+				return "golangci-lint run"
+			}
+		}
+		return "go vet ./..."
+	case "cargo":
+		return "cargo clippy -- -D warnings"
+	case "bundle":
+		deps := rubyDependencies(path)
+		if _, ok := deps["standard"]; ok {
+			return "bundle exec standardrb"
+		}
+		if _, ok := deps["rubocop"]; ok || fileExists(path, ".rubocop.yml") {
+			return "bundle exec rubocop"
+		}
+	case "composer":
+		if _, ok := phpDependencies(path)["phpstan/phpstan"]; ok || fileExists(path, "phpstan.neon") || fileExists(path, "phpstan.neon.dist") {
+			return "vendor/bin/phpstan analyse"
+		}
+	case "dotnet":
+		return "dotnet format --verify-no-changes"
+	case "swift":
+		if fileExists(path, ".swiftlint.yml") {
+			return "swiftlint"
+		}
+	case "mix":
+		if _, ok := elixirDependencies(path)["credo"]; ok {
+			return "mix credo"
+		}
+		return "mix format --check-formatted"
+	case "dart", "flutter":
+		return buildTool + " analyze"
+	case "deno":
+		return "deno lint"
+	case "stack", "cabal":
+		if fileExists(path, ".hlint.yaml") {
+			return "hlint ."
+		}
+	case "dune", "opam":
+		return "dune build @fmt"
+	case "zig":
+		return "zig fmt --check ."
+	case "shards":
+		return "crystal tool format --check"
+	case "luarocks":
+		if fileExists(path, ".luacheckrc") {
+			return "luacheck ."
+		}
+	case "cpanm", "carton", "dzil", "module-build", "make":
+		if fileExists(path, ".perlcriticrc") {
+			return "perlcritic lib"
+		}
+	}
+	return ""
+}
+
+// runCommand starts the application when the project has a recognisable entry point
+func runCommand(path, buildTool string) string {
+	switch buildTool {
+	case "pip", "poetry", "pdm", "pipenv", "uv", "hatch":
+		prefix := pythonRunPrefix(buildTool)
+		if fileExists(path, "manage.py") {
+			return prefix + "python manage.py runserver"
+		}
+		pyproject := readFile(filepath.Join(path, "pyproject.toml"))
+		for _, table := range []string{"project.scripts", "tool.poetry.scripts"} {
+			if names := tomlTableKeys(pyproject, table); len(names) > 0 {
+				return prefix + names[0]
+			}
+		}
+	case "maven":
+		if strings.Contains(readFile(filepath.Join(path, "pom.xml")), "spring-boot-maven-plugin") {
+			return mavenCommand(path) + " spring-boot:run"
+		}
+	case "gradle":
+		build := readFile(filepath.Join(path, "build.gradle.kts")) + readFile(filepath.Join(path, "build.gradle"))
+		if strings.Contains(build, "org.springframework.boot") {
+			return gradleCommand(path) + " bootRun"
+		}
+		if regexp.MustCompile(`(?m)id\s*\(?\s*["']application["']|^\s*application\s*$|apply plugin:\s*['"]application['"]`).MatchString(build) {
+			return gradleCommand(path) + " run"
+		}
+	case "sbt":
+		return "sbt run"
+	case "go":
+		if fileExists(path, "main.go") {
+			return "go run ."
+		}
+		if mains := globFiles(path, filepath.Join("cmd", "*", "main.go")); len(mains) == 1 {
+			return "go run ./cmd/" + filepath.Base(filepath.Dir(mains[0]))
+		}
+	case "cargo", "swift", "stack", "cabal", "nimble", "lein", "dart", "flutter":
+		return buildTool + " run"
+	case "dotnet":
+		return detectDotNetRunCommand(path)
+	case "bundle":
+		if _, ok := rubyDependencies(path)["rails"]; ok {
+			return "bundle exec rails server"
+		}
+		if fileExists(path, "config.ru") {
+			return "bundle exec rackup"
+		}
+	case "composer":
+		if fileExists(path, "artisan") {
+			return "php artisan serve"
+		}
+	case "mix":
+		if _, ok := elixirDependencies(path)["phoenix"]; ok {
+			return "mix phx.server"
+		}
+	case "zig":
+		return "zig build run"
+	}
+	return ""
+}
+
+// detectDotNetRunCommand runs the single executable or web project in a solution
+func detectDotNetRunCommand(path string) string {
+	app := regexp.MustCompile(`Sdk="Microsoft\.NET\.Sdk\.Web"|<OutputType>Exe</OutputType>`)
+	var projects []string
+	for _, pattern := range []string{"*.csproj", "*/*.csproj", "*/*/*.csproj"} {
+		for _, file := range globFiles(path, pattern) {
+			if app.MatchString(readFile(file)) {
+				projects = append(projects, file)
+			}
+		}
+	}
+	if len(projects) != 1 {
+		return ""
+	}
+	rel, _ := filepath.Rel(path, projects[0])
+	if filepath.Dir(rel) == "." {
+		return "dotnet run"
+	}
+	return "dotnet run --project " + filepath.ToSlash(rel)
+}
+
+// projectScripts maps build, test, lint and run to entry points the project defines:
+// package.json scripts, deno.json tasks, pyproject task runners and Makefile targets
+func projectScripts(path, buildTool string) map[string]string {
+	tasks := map[string]string{"build": "build", "test": "test", "lint": "lint", "run": "run", "start": "run"}
+	scripts := make(map[string]string)
+
+	// Makefile targets are the most generic, so more specific runners override them
+	for target := range makeTargets(path) {
+		if task, ok := tasks[target]; ok && target != "start" {
+			scripts[task] = "make " + target
+		}
+	}
+
+	add := func(names []string, command func(name string) string) {
+		for _, name := range names {
+			if task, ok := tasks[name]; ok {
+				scripts[task] = command(name)
+			}
+		}
+	}
+	switch buildTool {
+	case "npm", "yarn", "pnpm", "bun":
+		names := make([]string, 0)
+		for name := range nodeScripts(path) {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		add(names, func(name string) string { return nodeRunCommand(buildTool, name) })
+	case "deno":
+		for _, file := range []string{"deno.json", "deno.jsonc"} {
+			var config struct {
+				Tasks map[string]interface{} `json:"tasks"`
+			}
+			if err := json.Unmarshal([]byte(readFile(filepath.Join(path, file))), &config); err == nil {
+				names := make([]string, 0, len(config.Tasks))
+				for name := range config.Tasks {
+					names = append(names, name)
+				}
+				slices.Sort(names)
+				add(names, func(name string) string { return "deno task " + name })
+			}
+		}
+	case "pip", "poetry", "pdm", "pipenv", "uv", "hatch":
+		pyproject := readFile(filepath.Join(path, "pyproject.toml"))
+		prefix := pythonRunPrefix(buildTool)
+		add(tomlTableKeys(pyproject, "tool.poe.tasks"), func(name string) string { return prefix + "poe " + name })
+		add(tomlTableKeys(pyproject, "tool.taskipy.tasks"), func(name string) string { return prefix + "task " + name })
+		add(tomlTableKeys(pyproject, "tool.hatch.envs.default.scripts"), func(name string) string { return "hatch run " + name })
+		add(tomlTableKeys(pyproject, "tool.pdm.scripts"), func(name string) string { return "pdm run " + name })
+	}
+	return scripts
+}
+
+// makeTargets lists the explicit targets of a Makefile
+func makeTargets(path string) map[string]bool {
+	targets := make(map[string]bool)
+	for _, file := range []string{"GNUmakefile", "makefile", "Makefile"} {
+		// "name:" but not "name := value"
+		re := regexp.MustCompile(`(?m)^([A-Za-z0-9][\w.-]*)\s*:(?:[^=]|$)`)
+		for _, match := range re.FindAllStringSubmatch(readFile(filepath.Join(path, file)), -1) {
+			targets[match[1]] = true
+		}
+	}
+	return targets
+}
+
+// tomlTableKeys lists the keys of a TOML table in file order, including
+// keys defined as sub-tables such as [tool.poe.tasks.test]
+func tomlTableKeys(content, table string) []string {
+	var keys []string
+	inTable := false
+	key := regexp.MustCompile(`^"?([\w.-]+)"?\s*=`)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			header := strings.Trim(line, "[] ")
+			inTable = header == table
+			if name, ok := strings.CutPrefix(header, table+"."); ok && !slices.Contains(keys, name) {
+				keys = append(keys, name)
+			}
+			continue
+		}
+		if inTable {
+			if match := key.FindStringSubmatch(line); match != nil {
+				keys = append(keys, match[1])
+			}
+		}
+	}
+	return keys
+}
+
+// pythonRunPrefix runs a command inside the environment managed by a Python build tool
+func pythonRunPrefix(buildTool string) string {
+	if buildTool == "pip" {
+		return ""
+	}
+	return buildTool + " run "
+}
+
+// mavenCommand prefers the Maven wrapper committed with the project
+func mavenCommand(path string) string {
+	if fileExists(path, "mvnw") {
+		return "./mvnw"
+	}
+	return "mvn"
+}
+
+// gradleCommand prefers the Gradle wrapper committed with the project
+func gradleCommand(path string) string {
+	if fileExists(path, "gradlew") {
+		return "./gradlew"
+	}
+	return "gradle"
+}
+
+// nodeScripts reads the scripts section of package.json
+func nodeScripts(path string) map[string]string {
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal([]byte(readFile(filepath.Join(path, "package.json"))), &pkg); err != nil {
+		return nil
+	}
+	return pkg.Scripts
+}
+
+// nodeRunCommand runs a package.json script with the given package manager
+func nodeRunCommand(buildTool, script string) string {
+	if buildTool == "npm" && (script == "test" || script == "start") {
+		return "npm " + script
+	}
+	return buildTool + " run " + script
+}
+
+// nodeExecCommand runs a binary installed in node_modules with the given package manager
+func nodeExecCommand(buildTool string) string {
+	switch buildTool {
+	case "pnpm":
+		return "pnpm exec"
+	case "yarn":
+		return "yarn"
+	case "bun":
+		return "bunx"
+	default:
+		return "npx"
+	}
+}
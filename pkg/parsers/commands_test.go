@@ -0,0 +1,192 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+func TestDetectCommands(t *testing.T) {
+	tests := []struct {
+		name           string
+		language       string
+		buildTool      string
+		testFrameworks []string
+		files          map[string]string
+		expected       models.Commands
+	}{
+		{
+			name:      "Gradle wrapper with Spring Boot",
+			language:  "kotlin",
+			buildTool: "gradle",
+			files: map[string]string{
+				"gradlew":          "",
+				"build.gradle.kts": "plugins {\n  id(\"org.springframework.boot\") version \"3.3.0\"\n  id(\"com.diffplug.spotless\") version \"6.25.0\"\n}\n",
+			},
+			expected: models.Commands{Build: "./gradlew build -x test", Test: "./gradlew test", Lint: "./gradlew spotlessCheck", Run: "./gradlew bootRun"},
+		},
+		{
+			name:      "Gradle application plugin",
+			language:  "java",
+			buildTool: "gradle",
+			files:     map[string]string{"build.gradle": "plugins {\n    id 'application'\n}\n"},
+			expected:  models.Commands{Build: "gradle build -x test", Test: "gradle test", Run: "gradle run"},
+		},
+		{
+			name:      "Maven wrapper",
+			language:  "java",
+			buildTool: "maven",
+			files:     map[string]string{"pom.xml": "<project/>", "mvnw": ""},
+			expected:  models.Commands{Install: "./mvnw -B dependency:go-offline", Build: "./mvnw -B package -DskipTests", Test: "./mvnw -B test"},
+		},
+		{
+			name:           "Poetry with entry point and ruff",
+			language:       "python",
+			buildTool:      "poetry",
+			testFrameworks: []string{"pytest"},
+			files: map[string]string{
+				"pyproject.toml": "[tool.poetry]\nname = \"app\"\n\n[tool.poetry.scripts]\napp-cli = \"app.cli:main\"\n\n[tool.ruff]\nline-length = 100\n",
+			},
+			expected: models.Commands{
+				Install: "poetry install --no-interaction",
+				Build:   "poetry build",
+				Test:    "poetry run pytest",
+				Lint:    "poetry run ruff check .",
+				Run:     "poetry run app-cli",
+			},
+		},
+		{
+			name:           "Poe tasks override derived commands",
+			language:       "python",
+			buildTool:      "uv",
+			testFrameworks: []string{"pytest"},
+			files: map[string]string{
+				"pyproject.toml": "[tool.poe.tasks]\ntest = \"pytest -x\"\n\n[tool.poe.tasks.lint]\ncmd = \"ruff check\"\n",
+				"uv.lock":        "",
+			},
+			expected: models.Commands{Install: "uv sync --frozen", Build: "uv build", Test: "uv run poe test", Lint: "uv run poe lint"},
+		},
+		{
+			name:           "Django with requirements.txt",
+			language:       "python",
+			buildTool:      "pip",
+			testFrameworks: []string{"pytest"},
+			files:          map[string]string{"requirements.txt": "django\n", "manage.py": ""},
+			expected:       models.Commands{Install: "pip install -r requirements.txt", Test: "pytest", Run: "python manage.py runserver"},
+		},
+		{
+			name:           "npm scripts",
+			language:       "typescript",
+			buildTool:      "npm",
+			testFrameworks: []string{"jest"},
+			files: map[string]string{
+				"package.json":      `{"scripts": {"build": "tsc", "test": "jest", "lint": "eslint src", "start": "node dist/index.js"}}`,
+				"package-lock.json": "{}",
+			},
+			expected: models.Commands{Install: "npm ci", Build: "npm run build", Test: "npm test", Lint: "npm run lint", Run: "npm start"},
+		},
+		{
+			name:           "pnpm without scripts",
+			language:       "node",
+			buildTool:      "pnpm",
+			testFrameworks: []string{"vitest"},
+			files:          map[string]string{"package.json": `{"devDependencies": {"eslint": "^9.0.0"}}`},
+			expected:       models.Commands{Install: "pnpm install --frozen-lockfile", Test: "pnpm exec vitest run", Lint: "pnpm exec eslint ."},
+		},
+		{
+			name:      "Yarn Berry",
+			language:  "node",
+			buildTool: "yarn",
+			files:     map[string]string{"package.json": `{"scripts": {"start": "node ."}}`, ".yarnrc.yml": ""},
+			expected:  models.Commands{Install: "yarn install --immutable", Run: "yarn run start"},
+		},
+		{
+			name:           "Go with Makefile targets",
+			language:       "go",
+			buildTool:      "go",
+			testFrameworks: []string{"go-test"},
+			files: map[string]string{
+				"go.mod":          "module x\n",
+				"cmd/api/main.go": "package main\n",
+				"Makefile":        "VERSION := 1.0\n\nbuild:\n\tgo build -o bin/api ./cmd/api\n\nlint: vet\n\tgolangci-lint run\n",
+			},
+			expected: models.Commands{Install: "go mod download", Build: "make build", Test: "go test ./...", Lint: "make lint", Run: "go run ./cmd/api"},
+		},
+		{
+			name:           "Rails",
+			language:       "ruby",
+			buildTool:      "bundle",
+			testFrameworks: []string{"minitest"},
+			files:          map[string]string{"Gemfile": "gem 'rails'\ngem 'rubocop', require: false\n"},
+			expected:       models.Commands{Install: "bundle install", Test: "bundle exec rails test", Lint: "bundle exec rubocop", Run: "bundle exec rails server"},
+		},
+		{
+			name:           "Laravel",
+			language:       "php",
+			buildTool:      "composer",
+			testFrameworks: []string{"pest", "phpunit"},
+			files:          map[string]string{"composer.json": "{}", "artisan": ""},
+			expected:       models.Commands{Install: "composer install --no-interaction", Test: "vendor/bin/pest", Run: "php artisan serve"},
+		},
+		{
+			name:      ".NET web project",
+			language:  "csharp",
+			buildTool: "dotnet",
+			files: map[string]string{
+				"src/Api/Api.csproj":               `<Project Sdk="Microsoft.NET.Sdk.Web"></Project>`,
+				"tests/Api.Tests/Api.Tests.csproj": `<Project Sdk="Microsoft.NET.Sdk"></Project>`,
+			},
+			expected: models.Commands{
+				Install: "dotnet restore",
+				Build:   "dotnet build -c Release",
+				Test:    "dotnet test",
+				Lint:    "dotnet format --verify-no-changes",
+				Run:     "dotnet run --project src/Api/Api.csproj",
+			},
+		},
+		{
+			name:      "Deno tasks",
+			language:  "deno",
+			buildTool: "deno",
+			files:     map[string]string{"deno.json": `{"tasks": {"start": "deno run -A main.ts", "dev": "deno run --watch main.ts"}}`},
+			expected:  models.Commands{Install: "deno install", Test: "deno test", Lint: "deno lint", Run: "deno task start"},
+		},
+		{
+			name:      "CMake with Conan",
+			language:  "cpp",
+			buildTool: "cmake",
+			files:     map[string]string{"CMakeLists.txt": "", "conanfile.txt": ""},
+			expected: models.Commands{
+				Install: "conan install . --build=missing",
+				Build:   "cmake -S . -B build && cmake --build build",
+				Test:    "ctest --test-dir build",
+			},
+		},
+		{
+			name:      "Perl Makefile.PL",
+			language:  "perl",
+			buildTool: "make",
+			files:     map[string]string{"Makefile.PL": ""},
+			expected:  models.Commands{Install: "cpanm --installdeps .", Build: "perl Makefile.PL && make", Test: "perl Makefile.PL && make test"},
+		},
+		{
+			name:     "Shell scripts",
+			language: "bash",
+			files:    map[string]string{"deploy.sh": "#!/bin/bash\n"},
+			expected: models.Commands{Lint: "git ls-files '*.sh' | xargs shellcheck"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			result := DetectCommands(tmpDir, tt.language, tt.buildTool, DetectPackageManager(tmpDir, tt.language), tt.testFrameworks)
+			if result != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
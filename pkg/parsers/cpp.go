@@ -0,0 +1,186 @@
+package parsers
+
+import (
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var cppSourceExtensions = []string{".cpp", ".cc", ".cxx", ".c++", ".hpp", ".hh", ".hxx"}
+
+// isCppProject reports whether a C-family project uses C++
+func isCppProject(path string) bool {
+	if content := readFile(filepath.Join(path, "CMakeLists.txt")); content != "" {
+		if regexp.MustCompile(`CMAKE_CXX_STANDARD|cxx_std_\d+|project\s*\([^)]*\bCXX\b`).MatchString(content) {
+			return true
+		}
+	}
+	if content := readFile(filepath.Join(path, "meson.build")); content != "" {
+		if regexp.MustCompile(`project\s*\([^)]*'cpp'|cpp_std\s*=`).MatchString(content) {
+			return true
+		}
+	}
+	return hasFilesWithExtension(path, 3, cppSourceExtensions...)
+}
+
+// compilerHint matches a compiler selected in CMake, CMake presets or a Makefile,
+// e.g. set(CMAKE_CXX_COMPILER clang++-17), "CMAKE_C_COMPILER": "clang" or CC ?= gcc-13
+var compilerHint = regexp.MustCompile(`(?m)(?:CMAKE_(?:C|CXX)_COMPILER["']?\s*[:=]?\s*["']?|^\s*(?:CC|CXX)\s*[:?]?=\s*)(?:\S*/)?(clang|gcc|g\+\+)(?:\+\+)?(?:-(\d+))?(?:[^\w.+-]|$)`)
+
+// DetectCompiler detects the C or C++ compiler a project selects, with its major
+// version when the project names one. It returns empty strings when the project
+// leaves the compiler to the environment.
+func DetectCompiler(path, language string) (name, version string) {
+	if language != "c" && language != "cpp" {
+		return "", ""
+	}
+	for _, file := range []string{"CMakeLists.txt", "CMakePresets.json", "GNUmakefile", "makefile", "Makefile"} {
+		if match := compilerHint.FindStringSubmatch(readFile(filepath.Join(path, file))); match != nil {
+			if match[1] == "g++" {
+				return "gcc", match[2]
+			}
+			return match[1], match[2]
+		}
+	}
+	return "", ""
+}
+
+// C and C++ detection
+func detectCStandard(path, language string) string {
+	var cmakePatterns, mesonPattern string
+	if language == "cpp" {
+		cmakePatterns = `CMAKE_CXX_STANDARD\s+(\d+)|CXX_STANDARD\s+(\d+)|cxx_std_(\d+)`
+		mesonPattern = `cpp_std\s*=\s*(?:c|gnu)\+\+(\d+)`
+	} else {
+		cmakePatterns = `CMAKE_C_STANDARD\s+(\d+)|C_STANDARD\s+(\d+)|c_std_(\d+)`
+		mesonPattern = `c_std\s*=\s*(?:c|gnu)(\d+)`
+	}
+
+	if content := readFile(filepath.Join(path, "CMakeLists.txt")); content != "" {
+		if match := regexp.MustCompile(cmakePatterns).FindStringSubmatch(content); match != nil {
+			for _, group := range match[1:] {
+				if group != "" {
+					return group
+				}
+			}
+		}
+	}
+	if content := readFile(filepath.Join(path, "meson.build")); content != "" {
+		if match := regexp.MustCompile(mesonPattern).FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// IsMakefileProject reports whether a Makefile builds sources with one of the
+// given extensions, looking a few directories deep as Makefiles often build
+// sources outside the project root, e.g. lib/x.c
+func IsMakefileProject(path string, extensions ...string) bool {
+	return hasMakefile(path) && hasFilesWithExtension(path, 3, extensions...)
+}
+
+// hasMakefile reports whether a project has a Makefile under any name make reads
+func hasMakefile(path string) bool {
+	return fileExists(path, "Makefile") || fileExists(path, "makefile") || fileExists(path, "GNUmakefile")
+}
+
+func detectCBuildTool(path string) string {
+	if fileExists(path, "CMakeLists.txt") {
+		return "cmake"
+	}
+	if fileExists(path, "meson.build") {
+		return "meson"
+	}
+	if hasMakefile(path) {
+		return "make"
+	}
+	// Conan and vcpkg are usually driven through CMake
+	if detectCPackageManager(path) != "" {
+		return "cmake"
+	}
+	return "make"
+}
+
+func detectCPackageManager(path string) string {
+	if fileExists(path, "conanfile.txt") || fileExists(path, "conanfile.py") {
+		return "conan"
+	}
+	if fileExists(path, "vcpkg.json") {
+		return "vcpkg"
+	}
+	return ""
+}
+
+// detectCBuildSystemVersion reads the minimum CMake or Meson version a project requires
+func detectCBuildSystemVersion(path, buildTool string) string {
+	switch buildTool {
+	case "cmake":
+		if content := readFile(filepath.Join(path, "CMakeLists.txt")); content != "" {
+			re := regexp.MustCompile(`(?i)cmake_minimum_required\s*\(\s*VERSION\s+([\d.]+)`)
+			if match := re.FindStringSubmatch(content); match != nil {
+				return match[1]
+			}
+		}
+	case "meson":
+		if content := readFile(filepath.Join(path, "meson.build")); content != "" {
+			re := regexp.MustCompile(`meson_version\s*:\s*'[>=\s]*([\d.]+)'`)
+			if match := re.FindStringSubmatch(content); match != nil {
+				return match[1]
+			}
+		}
+	}
+	return ""
+}
+
+// hasFilesWithExtension walks up to maxDepth directories looking for a file with
+// one of the given extensions, skipping hidden and dependency directories
+func hasFilesWithExtension(path string, maxDepth int, extensions ...string) bool {
+	return hasFileMatching(path, maxDepth, func(name string) bool {
+		ext := strings.ToLower(filepath.Ext(name))
+		for _, want := range extensions {
+			if ext == want {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// hasFileMatching walks up to maxDepth directories looking for a file whose name
+// satisfies match, skipping hidden and dependency directories
+func hasFileMatching(path string, maxDepth int, match func(name string) bool) bool {
+	found := false
+	_ = filepath.WalkDir(path, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			rel, _ := filepath.Rel(path, p)
+			if rel != "." && (strings.HasPrefix(entry.Name(), ".") || SkippedDirs[entry.Name()] ||
+				strings.Count(rel, string(filepath.Separator)) >= maxDepth) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if match(entry.Name()) {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
+// SkippedDirs are dependency and build output directories that never describe the project itself
+var SkippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"build":        true,
+	"dist":         true,
+	"target":       true,
+	"third_party":  true,
+	"venv":         true,
+	"__pycache__":  true,
+}
@@ -0,0 +1,116 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectCProjects(t *testing.T) {
+	tests := []struct {
+		name             string
+		files            map[string]string
+		expectedLanguage string
+		expectedStandard string
+		expectedBuild    string
+		expectedPackages string
+		expectedCMake    string
+	}{
+		{
+			name: "CMake C++ with Conan",
+			files: map[string]string{
+				"CMakeLists.txt": "cmake_minimum_required(VERSION 3.16)\nproject(app LANGUAGES CXX)\nset(CMAKE_CXX_STANDARD 20)\n",
+				"conanfile.txt":  "[requires]\nfmt/10.2.1\n",
+			},
+			expectedLanguage: "cpp",
+			expectedStandard: "20",
+			expectedBuild:    "cmake",
+			expectedPackages: "conan",
+			expectedCMake:    "3.16",
+		},
+		{
+			name: "CMake C project",
+			files: map[string]string{
+				"CMakeLists.txt": "cmake_minimum_required(VERSION 3.10)\nproject(lib C)\nset(CMAKE_C_STANDARD 11)\n",
+				"src/lib.c":      "int main(void) { return 0; }",
+			},
+			expectedLanguage: "c",
+			expectedStandard: "11",
+			expectedBuild:    "cmake",
+			expectedCMake:    "3.10",
+		},
+		{
+			name: "Meson C++ with vcpkg",
+			files: map[string]string{
+				"meson.build": "project('app', 'cpp', default_options: ['cpp_std=c++17'])",
+				"vcpkg.json":  `{"name": "app"}`,
+			},
+			expectedLanguage: "cpp",
+			expectedStandard: "17",
+			expectedBuild:    "meson",
+			expectedPackages: "vcpkg",
+		},
+		{
+			name: "Makefile-only C++",
+			files: map[string]string{
+				"Makefile":     "all:\n\tg++ -o app src/main.cpp\n",
+				"src/main.cpp": "int main() {}",
+			},
+			expectedLanguage: "cpp",
+			expectedBuild:    "make",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			language := RefineLanguage(tmpDir, "c")
+			if language != tt.expectedLanguage {
+				t.Fatalf("Expected language %q, got %q", tt.expectedLanguage, language)
+			}
+			buildTool := DetectBuildTool(tmpDir, language)
+			if buildTool != tt.expectedBuild {
+				t.Errorf("Expected build tool %q, got %q", tt.expectedBuild, buildTool)
+			}
+			if result := DetectVersion(tmpDir, language, buildTool); result != tt.expectedStandard {
+				t.Errorf("Expected standard %q, got %q", tt.expectedStandard, result)
+			}
+			if result := DetectPackageManager(tmpDir, language); result != tt.expectedPackages {
+				t.Errorf("Expected package manager %q, got %q", tt.expectedPackages, result)
+			}
+			if _, version := DetectToolchain(tmpDir, language, buildTool); version != tt.expectedCMake {
+				t.Errorf("Expected build system version %q, got %q", tt.expectedCMake, version)
+			}
+		})
+	}
+}
+
+func TestDetectCompiler(t *testing.T) {
+	tests := []struct {
+		name            string
+		language        string
+		files           map[string]string
+		expectedName    string
+		expectedVersion string
+	}{
+		{"CMake compiler with version", "cpp", map[string]string{"CMakeLists.txt": "set(CMAKE_CXX_COMPILER clang++-17)\nproject(app CXX)\n"}, "clang", "17"},
+		{"CMake preset", "cpp", map[string]string{"CMakePresets.json": `{"configurePresets": [{"name": "default", "cacheVariables": {"CMAKE_CXX_COMPILER": "clang++"}}]}`}, "clang", ""},
+		{"Makefile CC", "c", map[string]string{"Makefile": "CC ?= /usr/bin/gcc-13\nall:\n\t$(CC) -o app main.c\n"}, "gcc", "13"},
+		{"Makefile CXX g++", "cpp", map[string]string{"Makefile": "CXX = g++\n"}, "gcc", ""},
+		{"No compiler selected", "c", map[string]string{"CMakeLists.txt": "project(app C)\n"}, "", ""},
+		{"Other languages", "go", map[string]string{"Makefile": "CC = clang\n"}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			name, version := DetectCompiler(tmpDir, tt.language)
+			if name != tt.expectedName || version != tt.expectedVersion {
+				t.Errorf("Expected %q %q, got %q %q", tt.expectedName, tt.expectedVersion, name, version)
+			}
+		})
+	}
+}
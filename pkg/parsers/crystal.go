@@ -0,0 +1,25 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Crystal detection
+func detectCrystalVersion(path string) string {
+	if content := readFile(filepath.Join(path, ".crystal-version")); content != "" {
+		return strings.TrimSpace(content)
+	}
+	if version := toolVersionsEntry(path, "crystal"); version != "" {
+		return version
+	}
+	// shard.yml: crystal: ">= 1.10.0" or crystal: 1.10.0
+	if content := readFile(filepath.Join(path, "shard.yml")); content != "" {
+		re := regexp.MustCompile(`(?m)^crystal:\s*["']?[<>=~\s]*(\d+\.\d+(?:\.\d+)?)`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectCrystalVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{"shard.yml constraint", map[string]string{"shard.yml": "name: app\nversion: 0.1.0\ncrystal: \">= 1.10.0\"\n"}, "1.10.0"},
+		{"shard.yml exact", map[string]string{"shard.yml": "name: app\ncrystal: 1.11.2\n"}, "1.11.2"},
+		{".crystal-version", map[string]string{"shard.yml": "name: app\n", ".crystal-version": "1.12.1"}, "1.12.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectVersion(tmpDir, "crystal", "shards"); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
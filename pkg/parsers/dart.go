@@ -0,0 +1,90 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Pubspec represents the parts of a Dart pubspec.yaml used for detection
+type Pubspec struct {
+	Environment struct {
+		SDK     string `yaml:"sdk"`
+		Flutter string `yaml:"flutter"`
+	} `yaml:"environment"`
+	Dependencies map[string]interface{} `yaml:"dependencies"`
+}
+
+func readPubspec(path string) *Pubspec {
+	content := readFile(filepath.Join(path, "pubspec.yaml"))
+	if content == "" {
+		return nil
+	}
+	var pubspec Pubspec
+	if err := yaml.Unmarshal([]byte(content), &pubspec); err != nil {
+		return nil
+	}
+	return &pubspec
+}
+
+// isFlutterProject reports whether a Dart project depends on the Flutter SDK
+func isFlutterProject(path string) bool {
+	if fileExists(path, ".fvmrc") || fileExists(path, filepath.Join(".fvm", "fvm_config.json")) {
+		return true
+	}
+	pubspec := readPubspec(path)
+	if pubspec == nil {
+		return false
+	}
+	if pubspec.Environment.Flutter != "" {
+		return true
+	}
+	if dep, ok := pubspec.Dependencies["flutter"].(map[string]interface{}); ok {
+		return dep["sdk"] == "flutter"
+	}
+	return false
+}
+
+// Dart detection
+func detectDartVersion(path string) string {
+	if pubspec := readPubspec(path); pubspec != nil {
+		// Lower bound of the SDK constraint, e.g. ">=3.2.0 <4.0.0" or "^3.2.0"
+		re := regexp.MustCompile(`(\d+)\.(\d+)`)
+		if match := re.FindStringSubmatch(pubspec.Environment.SDK); match != nil {
+			return match[1] + "." + match[2]
+		}
+	}
+	return ""
+}
+
+// Flutter detection
+func detectFlutterVersion(path string) string {
+	// FVM pins the exact Flutter SDK
+	if content := readFile(filepath.Join(path, ".fvmrc")); content != "" {
+		var fvmrc struct {
+			Flutter string `json:"flutter"`
+		}
+		if err := json.Unmarshal([]byte(content), &fvmrc); err == nil && fvmrc.Flutter != "" {
+			return fvmrc.Flutter
+		}
+	}
+	if content := readFile(filepath.Join(path, ".fvm", "fvm_config.json")); content != "" {
+		var fvmConfig struct {
+			FlutterSdkVersion string `json:"flutterSdkVersion"`
+		}
+		if err := json.Unmarshal([]byte(content), &fvmConfig); err == nil && fvmConfig.FlutterSdkVersion != "" {
+			return fvmConfig.FlutterSdkVersion
+		}
+	}
+
+	if pubspec := readPubspec(path); pubspec != nil {
+		re := regexp.MustCompile(`\d+\.\d+\.\d+`)
+		if match := re.FindString(pubspec.Environment.Flutter); match != "" {
+			return match
+		}
+	}
+
+	return ""
+}
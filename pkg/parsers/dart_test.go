@@ -0,0 +1,78 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRefineDartLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{"Pure Dart package", map[string]string{"pubspec.yaml": "name: cli\nenvironment:\n  sdk: '>=3.2.0 <4.0.0'\n"}, "dart"},
+		{"Flutter SDK dependency", map[string]string{"pubspec.yaml": "name: app\ndependencies:\n  flutter:\n    sdk: flutter\n"}, "flutter"},
+		{"Flutter environment constraint", map[string]string{"pubspec.yaml": "name: app\nenvironment:\n  sdk: ^3.2.0\n  flutter: '>=3.16.0'\n"}, "flutter"},
+		{"FVM config", map[string]string{"pubspec.yaml": "name: app\n", ".fvmrc": `{"flutter": "3.19.0"}`}, "flutter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := RefineLanguage(tmpDir, "dart"); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDetectFlutterVersions(t *testing.T) {
+	tests := []struct {
+		name            string
+		files           map[string]string
+		expectedDart    string
+		expectedFlutter string
+	}{
+		{
+			name:            "pubspec constraints",
+			files:           map[string]string{"pubspec.yaml": "environment:\n  sdk: '>=3.2.0 <4.0.0'\n  flutter: '>=3.16.0'\n"},
+			expectedDart:    "3.2",
+			expectedFlutter: "3.16.0",
+		},
+		{
+			name: ".fvmrc pin",
+			files: map[string]string{
+				"pubspec.yaml": "environment:\n  sdk: ^3.3.0\n",
+				".fvmrc":       `{"flutter": "3.19.6"}`,
+			},
+			expectedDart:    "3.3",
+			expectedFlutter: "3.19.6",
+		},
+		{
+			name: "legacy fvm_config.json pin",
+			files: map[string]string{
+				"pubspec.yaml":         "environment:\n  sdk: '>=2.19.0 <3.0.0'\n",
+				".fvm/fvm_config.json": `{"flutterSdkVersion": "3.7.12"}`,
+			},
+			expectedDart:    "2.19",
+			expectedFlutter: "3.7.12",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectVersion(tmpDir, "flutter", "flutter"); result != tt.expectedDart {
+				t.Errorf("Expected Dart version %q, got %q", tt.expectedDart, result)
+			}
+			if result := DetectRuntimeVersion(tmpDir, "flutter", "flutter"); result != tt.expectedFlutter {
+				t.Errorf("Expected Flutter version %q, got %q", tt.expectedFlutter, result)
+			}
+		})
+	}
+}
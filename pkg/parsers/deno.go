@@ -0,0 +1,80 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// isDenoProject reports whether a JavaScript/TypeScript project runs on Deno
+func isDenoProject(path string) bool {
+	return fileExists(path, "deno.json") || fileExists(path, "deno.jsonc") || fileExists(path, "deno.lock")
+}
+
+// isBunProject reports whether a JavaScript/TypeScript project runs on Bun
+func isBunProject(path string) bool {
+	for _, file := range []string{"bun.lockb", "bun.lock", "bunfig.toml", ".bun-version"} {
+		if fileExists(path, file) {
+			return true
+		}
+	}
+	return detectBunVersion(path) != ""
+}
+
+// Deno detection
+func detectDenoVersion(path string) string {
+	if content := readFile(filepath.Join(path, ".dvmrc")); content != "" {
+		return strings.TrimPrefix(strings.TrimSpace(content), "v")
+	}
+	if version := toolVersionsEntry(path, "deno"); version != "" {
+		return version
+	}
+
+	// deno.json does not pin the runtime, but the lockfile format implies its major version
+	if content := readFile(filepath.Join(path, "deno.lock")); content != "" {
+		var lock struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal([]byte(content), &lock); err == nil {
+			switch lock.Version {
+			case "2", "3":
+				return "1"
+			case "4", "5":
+				return "2"
+			}
+		}
+	}
+
+	return ""
+}
+
+// Bun detection
+func detectBunVersion(path string) string {
+	if content := readFile(filepath.Join(path, ".bun-version")); content != "" {
+		return strings.TrimPrefix(strings.TrimSpace(content), "v")
+	}
+	if version := toolVersionsEntry(path, "bun"); version != "" {
+		return version
+	}
+
+	if content := readFile(filepath.Join(path, "package.json")); content != "" {
+		var pkg struct {
+			Engines struct {
+				Bun string `json:"bun"`
+			} `json:"engines"`
+			PackageManager string `json:"packageManager"`
+		}
+		if err := json.Unmarshal([]byte(content), &pkg); err == nil {
+			re := regexp.MustCompile(`\d+(?:\.\d+){0,2}`)
+			if version := re.FindString(pkg.Engines.Bun); version != "" {
+				return version
+			}
+			if strings.HasPrefix(pkg.PackageManager, "bun@") {
+				return re.FindString(pkg.PackageManager)
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,59 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRefineJavaScriptRuntime(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		files    map[string]string
+		expected string
+	}{
+		{"Node.js project", "node", map[string]string{"package.json": "{}", "package-lock.json": "{}"}, "node"},
+		{"Deno project", "typescript", map[string]string{"deno.json": `{"tasks": {}}`}, "deno"},
+		{"Deno with package.json", "node", map[string]string{"package.json": "{}", "deno.lock": `{"version": "4"}`}, "deno"},
+		{"Bun lockfile", "node", map[string]string{"package.json": "{}", "bun.lockb": ""}, "bun"},
+		{"Bun engines", "javascript", map[string]string{"package.json": `{"engines": {"bun": ">=1.1.0"}}`}, "bun"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := RefineLanguage(tmpDir, tt.language); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDetectDenoAndBunVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		files    map[string]string
+		expected string
+	}{
+		{".dvmrc", "deno", map[string]string{"deno.json": "{}", ".dvmrc": "v1.41.3\n"}, "1.41.3"},
+		{"deno.lock v4", "deno", map[string]string{"deno.lock": `{"version": "4"}`}, "2"},
+		{"deno.json only", "deno", map[string]string{"deno.json": "{}"}, ""},
+		{".bun-version", "bun", map[string]string{".bun-version": "1.1.8"}, "1.1.8"},
+		{"engines.bun", "bun", map[string]string{"package.json": `{"engines": {"bun": "^1.0.30"}}`}, "1.0.30"},
+		{"packageManager", "bun", map[string]string{"package.json": `{"packageManager": "bun@1.1.20"}`}, "1.1.20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectRuntimeVersion(tmpDir, tt.language, tt.language); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
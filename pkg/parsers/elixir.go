@@ -0,0 +1,71 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Elixir detection
+func detectElixirVersion(path string) string {
+	// .tool-versions pins an exact version, e.g. "elixir 1.15.7-otp-26"
+	if version := toolVersionsEntry(path, "elixir"); version != "" {
+		if idx := strings.Index(version, "-otp-"); idx != -1 {
+			version = version[:idx]
+		}
+		return version
+	}
+
+	// mix.exs declares a requirement, e.g. elixir: "~> 1.15"
+	if content := readFile(filepath.Join(path, "mix.exs")); content != "" {
+		re := regexp.MustCompile(`elixir:\s*"[~>=\s]*(\d+\.\d+)`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+
+	return ""
+}
+
+// detectOTPVersion detects the Erlang/OTP version used by Elixir and Erlang projects
+func detectOTPVersion(path string) string {
+	if version := toolVersionsEntry(path, "erlang"); version != "" {
+		return version
+	}
+
+	// An Elixir pin built for a specific OTP, e.g. "elixir 1.15.7-otp-26"
+	if version := toolVersionsEntry(path, "elixir"); version != "" {
+		if idx := strings.Index(version, "-otp-"); idx != -1 {
+			return version[idx+len("-otp-"):]
+		}
+	}
+
+	// rebar.config declares a minimum, e.g. {minimum_otp_vsn, "25.0"}
+	if content := readFile(filepath.Join(path, "rebar.config")); content != "" {
+		re := regexp.MustCompile(`\{\s*minimum_otp_vsn\s*,\s*"(\d+)`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+
+	return ""
+}
+
+func detectErlangBuildTool(path string) string {
+	if fileExists(path, "erlang.mk") {
+		return "erlang.mk"
+	}
+	return "rebar3"
+}
+
+// toolVersionsEntry reads a tool's version from an asdf/mise .tool-versions file
+func toolVersionsEntry(path, tool string) string {
+	content := readFile(filepath.Join(path, ".tool-versions"))
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == tool {
+			return fields[1]
+		}
+	}
+	return ""
+}
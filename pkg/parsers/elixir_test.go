@@ -0,0 +1,65 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectElixirVersions(t *testing.T) {
+	tests := []struct {
+		name            string
+		language        string
+		files           map[string]string
+		expectedVersion string
+		expectedOTP     string
+	}{
+		{
+			name:            "mix.exs requirement",
+			language:        "elixir",
+			files:           map[string]string{"mix.exs": `def project do [app: :web, elixir: "~> 1.15", deps: deps()] end`},
+			expectedVersion: "1.15",
+			expectedOTP:     "",
+		},
+		{
+			name:     ".tool-versions with OTP suffix",
+			language: "elixir",
+			files: map[string]string{
+				"mix.exs":        `elixir: "~> 1.14"`,
+				".tool-versions": "elixir 1.15.7-otp-26\nnodejs 20.10.0\n",
+			},
+			expectedVersion: "1.15.7",
+			expectedOTP:     "26",
+		},
+		{
+			name:     ".tool-versions with erlang entry",
+			language: "elixir",
+			files: map[string]string{
+				"mix.exs":        `elixir: "~> 1.16"`,
+				".tool-versions": "erlang 26.2.1\nelixir 1.16.0-otp-26\n",
+			},
+			expectedVersion: "1.16.0",
+			expectedOTP:     "26.2.1",
+		},
+		{
+			name:            "rebar.config minimum_otp_vsn",
+			language:        "erlang",
+			files:           map[string]string{"rebar.config": `{minimum_otp_vsn, "25.0"}.`},
+			expectedVersion: "25",
+			expectedOTP:     "25",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectVersion(tmpDir, tt.language, ""); result != tt.expectedVersion {
+				t.Errorf("Expected version %q, got %q", tt.expectedVersion, result)
+			}
+			if result := DetectRuntimeVersion(tmpDir, tt.language, ""); result != tt.expectedOTP {
+				t.Errorf("Expected OTP version %q, got %q", tt.expectedOTP, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,433 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+// frameworkDependency maps a framework to the package that identifies it
+type frameworkDependency struct {
+	Name    string
+	Package string
+}
+
+// frameworkDependencies lists known frameworks per package ecosystem, in output order
+var frameworkDependencies = map[string][]frameworkDependency{
+	"python": {
+		{"django", "django"},
+		{"fastapi", "fastapi"},
+		{"flask", "flask"},
+	},
+	"node": {
+		{"nextjs", "next"},
+		{"nuxt", "nuxt"},
+		{"nestjs", "@nestjs/core"},
+		{"sveltekit", "@sveltejs/kit"},
+		{"angular", "@angular/core"},
+		{"express", "express"},
+		{"vite", "vite"},
+		{"react", "react"},
+		{"vue", "vue"},
+	},
+	"ruby": {
+		{"rails", "rails"},
+		{"sinatra", "sinatra"},
+		{"hanami", "hanami"},
+	},
+	"php": {
+		{"laravel", "laravel/framework"},
+		{"symfony", "symfony/framework-bundle"},
+	},
+	"go": {
+		{"gin", "github.com/gin-gonic/gin"},
+		{"echo", "github.com/labstack/echo"},
+		{"fiber", "github.com/gofiber/fiber"},
+		{"chi", "github.com/go-chi/chi"},
+	},
+	"elixir": {
+		{"phoenix", "phoenix"},
+	},
+}
+
+// DetectFrameworks detects application frameworks and their versions.
+// Versions come from lockfiles when present, otherwise from the lower bound
+// of the manifest constraint.
+func DetectFrameworks(path, language string) []models.Framework {
+	switch language {
+	case "python":
+		return matchFrameworks("python", pythonDependencies(path))
+	case "java", "kotlin", "scala":
+		return detectJVMFrameworks(path)
+	case "node", "javascript", "typescript", "bun":
+		return matchFrameworks("node", nodeDependencies(path))
+	case "ruby":
+		return matchFrameworks("ruby", rubyDependencies(path))
+	case "php":
+		return matchFrameworks("php", phpDependencies(path))
+	case "go":
+		return matchFrameworks("go", goDependencies(path))
+	case "elixir":
+		return matchFrameworks("elixir", elixirDependencies(path))
+	case "dotnet", "csharp":
+		return detectDotNetFrameworks(path)
+	default:
+		return nil
+	}
+}
+
+// matchFrameworks picks the known frameworks of an ecosystem out of a dependency map
+func matchFrameworks(ecosystem string, dependencies map[string]string) []models.Framework {
+	var frameworks []models.Framework
+	for _, dep := range frameworkDependencies[ecosystem] {
+		if version, ok := dependencies[dep.Package]; ok {
+			frameworks = append(frameworks, models.Framework{Name: dep.Name, Version: version})
+		}
+	}
+	return frameworks
+}
+
+// constraintVersion extracts the lowest version a constraint allows, e.g. "^14.1" -> "14.1"
+func constraintVersion(constraint string) string {
+	return regexp.MustCompile(`\d+(?:\.\d+){0,2}`).FindString(constraint)
+}
+
+// pinLocked replaces manifest constraints with the exact versions from a lockfile.
+// Only direct dependencies are kept; lockfiles also list transitive ones.
+func pinLocked(dependencies, locked map[string]string) {
+	for name := range dependencies {
+		if version := locked[name]; version != "" {
+			dependencies[name] = version
+		}
+	}
+}
+
+// pythonPackageName normalizes a distribution name as pip does
+func pythonPackageName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+func pythonDependencies(path string) map[string]string {
+	deps := make(map[string]string)
+
+	// PEP 508 requirement strings: requirements.txt, PEP 621 dependencies, setup.py
+	requirement := regexp.MustCompile(`(?m)^\s*["']?([A-Za-z0-9][A-Za-z0-9_.-]*)(?:\[[^\]]*\])?\s*(?:(?:==|>=|~=|>)\s*([\d.]+))?`)
+	if content := readFile(filepath.Join(path, "requirements.txt")); content != "" {
+		for _, match := range requirement.FindAllStringSubmatch(content, -1) {
+			deps[pythonPackageName(match[1])] = match[2]
+		}
+	}
+	quoted := regexp.MustCompile(`["']([A-Za-z0-9][A-Za-z0-9_.-]*)(?:\[[^\]]*\])?\s*(?:(?:==|>=|~=|>)\s*([\d.]+))?(?:\s*[;,<>=!~][^"']*)?["']`)
+	// Poetry and Pipfile tables: name = "^1.0" or name = { version = "^1.0", ... }
+	table := regexp.MustCompile(`(?m)^([A-Za-z0-9][A-Za-z0-9_.-]*)\s*=\s*(?:"([^"]*)"|\{[^}\n]*?version\s*=\s*"([^"]*)")`)
+	for _, file := range []string{"pyproject.toml", "Pipfile", "setup.py"} {
+		content := readFile(filepath.Join(path, file))
+		if content == "" {
+			continue
+		}
+		requirements, tables := content, content
+		switch file {
+		case "pyproject.toml":
+			requirements, tables = pyprojectDependencies(content)
+		case "Pipfile":
+			requirements = ""
+		case "setup.py":
+			tables = ""
+		}
+		for _, match := range quoted.FindAllStringSubmatch(requirements, -1) {
+			name := pythonPackageName(match[1])
+			if _, ok := deps[name]; !ok {
+				deps[name] = match[2]
+			}
+		}
+		for _, match := range table.FindAllStringSubmatch(tables, -1) {
+			deps[pythonPackageName(match[1])] = constraintVersion(match[2] + match[3])
+		}
+	}
+
+	pinLocked(deps, pythonLockedPackages(path))
+	return deps
+}
+
+// pyprojectDependencies extracts the dependency declarations of a pyproject.toml: PEP 508
+// requirement strings from [project].dependencies and [project.optional-dependencies], and
+// Poetry tables from [tool.poetry.dependencies] and its groups. Keywords, classifiers and
+// [tool.*] settings elsewhere in the file would otherwise read as packages.
+func pyprojectDependencies(content string) (requirements, poetry string) {
+	var req, tables strings.Builder
+	strs := regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	dependencies := regexp.MustCompile(`^\s*dependencies\s*=`)
+	table, depth, inArray := "", 0, false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if depth == 0 && strings.HasPrefix(trimmed, "[") {
+			header, _, _ := strings.Cut(trimmed, "#")
+			table = strings.Trim(header, "[] ")
+			continue
+		}
+		if depth == 0 {
+			inArray = table == "project" && dependencies.MatchString(line)
+		}
+		switch {
+		case inArray, table == "project.optional-dependencies":
+			req.WriteString(line + "\n")
+		case table == "tool.poetry.dependencies", table == "tool.poetry.dev-dependencies",
+			strings.HasPrefix(table, "tool.poetry.group.") && strings.HasSuffix(table, ".dependencies"):
+			tables.WriteString(line + "\n")
+		}
+		bare, _, _ := strings.Cut(strs.ReplaceAllString(line, ""), "#")
+		depth += strings.Count(bare, "[") - strings.Count(bare, "]")
+	}
+	return req.String(), tables.String()
+}
+
+// pythonLockedPackages lists every package pinned in a Python lockfile, including transitive ones
+func pythonLockedPackages(path string) map[string]string {
+	// poetry.lock, uv.lock and pdm.lock share the [[package]] name/version layout
+	locked := make(map[string]string)
+	lockEntry := regexp.MustCompile(`(?m)^name\s*=\s*"([^"]+)"\s*\nversion\s*=\s*"([^"]+)"`)
+	for _, file := range []string{"poetry.lock", "uv.lock", "pdm.lock"} {
+		for _, match := range lockEntry.FindAllStringSubmatch(readFile(filepath.Join(path, file)), -1) {
+			locked[pythonPackageName(match[1])] = match[2]
+		}
+	}
+	if content := readFile(filepath.Join(path, "Pipfile.lock")); content != "" {
+		var pipfileLock struct {
+			Default map[string]struct {
+				Version string `json:"version"`
+			} `json:"default"`
+		}
+		if err := json.Unmarshal([]byte(content), &pipfileLock); err == nil {
+			for name, pkg := range pipfileLock.Default {
+				locked[pythonPackageName(name)] = strings.TrimPrefix(pkg.Version, "==")
+			}
+		}
+	}
+	return locked
+}
+
+func nodeDependencies(path string) map[string]string {
+	deps := make(map[string]string)
+	content := readFile(filepath.Join(path, "package.json"))
+	if content == "" {
+		return deps
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal([]byte(content), &pkg); err != nil {
+		return deps
+	}
+	for name, constraint := range pkg.DevDependencies {
+		deps[name] = constraintVersion(constraint)
+	}
+	for name, constraint := range pkg.Dependencies {
+		deps[name] = constraintVersion(constraint)
+	}
+
+	pinLocked(deps, nodeLockedPackages(path))
+	return deps
+}
+
+// nodeLockedPackages lists every package pinned in a Node.js lockfile, including transitive ones
+func nodeLockedPackages(path string) map[string]string {
+	locked := make(map[string]string)
+	if content := readFile(filepath.Join(path, "package-lock.json")); content != "" {
+		var lock struct {
+			Packages map[string]struct {
+				Version string `json:"version"`
+			} `json:"packages"`
+		}
+		if err := json.Unmarshal([]byte(content), &lock); err == nil {
+			for key, entry := range lock.Packages {
+				idx := strings.LastIndex(key, "node_modules/")
+				if idx == -1 {
+					continue
+				}
+				// Hoisted packages win over copies nested under another package
+				name := key[idx+len("node_modules/"):]
+				if _, ok := locked[name]; !ok || idx == 0 {
+					locked[name] = entry.Version
+				}
+			}
+		}
+	}
+	// yarn.lock: `next@^14.1.0:` followed by `version "14.1.0"` (v1) or `version: 14.1.0` (berry)
+	yarnEntry := regexp.MustCompile(`(?m)^"?(@?[^@\s"]+)@[^\n]*:\n\s+version:?\s+"?([\d.]+)`)
+	for _, match := range yarnEntry.FindAllStringSubmatch(readFile(filepath.Join(path, "yarn.lock")), -1) {
+		locked[match[1]] = match[2]
+	}
+	// pnpm-lock.yaml packages: `  sharp@0.33.2:`, `  '@img/sharp-linux-x64@0.33.2':` or `  /sharp@0.32.6:` (v6)
+	pnpm := readFile(filepath.Join(path, "pnpm-lock.yaml"))
+	pnpmPackage := regexp.MustCompile(`(?m)^  '?/?(@?[^@\s'/]+(?:/[^@\s']+)?)@(\d[\d.]*)`)
+	for _, match := range pnpmPackage.FindAllStringSubmatch(pnpm, -1) {
+		locked[match[1]] = match[2]
+	}
+	// pnpm-lock.yaml importers: `next:` followed by `specifier:` and `version: 14.1.0(...)`
+	pnpmEntry := regexp.MustCompile(`(?m)^\s+'?(@?[\w./-]+)'?:\n\s+specifier:[^\n]*\n\s+version:\s*([\d.]+)`)
+	for _, match := range pnpmEntry.FindAllStringSubmatch(pnpm, -1) {
+		locked[match[1]] = match[2]
+	}
+	return locked
+}
+
+func rubyDependencies(path string) map[string]string {
+	deps := make(map[string]string)
+	gem := regexp.MustCompile(`(?m)^\s*gem\s+['"]([\w-]+)['"](?:\s*,\s*['"]([^'"]*)['"])?`)
+	for _, match := range gem.FindAllStringSubmatch(readFile(filepath.Join(path, "Gemfile")), -1) {
+		deps[match[1]] = constraintVersion(match[2])
+	}
+
+	pinLocked(deps, rubyLockedPackages(path))
+	return deps
+}
+
+// rubyLockedPackages lists every gem resolved in Gemfile.lock, including transitive ones
+func rubyLockedPackages(path string) map[string]string {
+	// Gemfile.lock lists resolved gems as `    rails (7.1.2)` or `    nokogiri (1.16.3-x86_64-linux)`
+	locked := make(map[string]string)
+	spec := regexp.MustCompile(`(?m)^    ([\w-]+) \(([\d.]+)[^)]*\)`)
+	for _, match := range spec.FindAllStringSubmatch(readFile(filepath.Join(path, "Gemfile.lock")), -1) {
+		locked[match[1]] = match[2]
+	}
+	return locked
+}
+
+func phpDependencies(path string) map[string]string {
+	deps := make(map[string]string)
+	if content := readFile(filepath.Join(path, "composer.json")); content != "" {
+		var composer struct {
+			Require    map[string]string `json:"require"`
+			RequireDev map[string]string `json:"require-dev"`
+		}
+		if err := json.Unmarshal([]byte(content), &composer); err == nil {
+			for name, constraint := range composer.RequireDev {
+				deps[name] = constraintVersion(constraint)
+			}
+			for name, constraint := range composer.Require {
+				deps[name] = constraintVersion(constraint)
+			}
+		}
+	}
+
+	locked := make(map[string]string)
+	if content := readFile(filepath.Join(path, "composer.lock")); content != "" {
+		var lock struct {
+			Packages []struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"packages"`
+		}
+		if err := json.Unmarshal([]byte(content), &lock); err == nil {
+			for _, pkg := range lock.Packages {
+				locked[pkg.Name] = strings.TrimPrefix(pkg.Version, "v")
+			}
+		}
+	}
+	pinLocked(deps, locked)
+	return deps
+}
+
+// goDependencies reads go.mod requirements, dropping major version suffixes from module paths
+func goDependencies(path string) map[string]string {
+	deps := make(map[string]string)
+	require := regexp.MustCompile(`(?m)^\s*(?:require\s+)?([\w.-]+\.[\w.-]+/[\w./-]+)\s+v(\d+\.\d+\.\d+)`)
+	majorSuffix := regexp.MustCompile(`/v\d+$`)
+	for _, match := range require.FindAllStringSubmatch(readFile(filepath.Join(path, "go.mod")), -1) {
+		deps[majorSuffix.ReplaceAllString(match[1], "")] = match[2]
+	}
+	return deps
+}
+
+func elixirDependencies(path string) map[string]string {
+	deps := make(map[string]string)
+	dep := regexp.MustCompile(`\{:(\w+),\s*"([^"]*)"`)
+	for _, match := range dep.FindAllStringSubmatch(readFile(filepath.Join(path, "mix.exs")), -1) {
+		deps[match[1]] = constraintVersion(match[2])
+	}
+
+	// mix.lock: "phoenix": {:hex, :phoenix, "1.7.10", ...}
+	locked := make(map[string]string)
+	entry := regexp.MustCompile(`"(\w+)":\s*\{:hex,\s*:\w+,\s*"([^"]+)"`)
+	for _, match := range entry.FindAllStringSubmatch(readFile(filepath.Join(path, "mix.lock")), -1) {
+		locked[match[1]] = match[2]
+	}
+	pinLocked(deps, locked)
+	return deps
+}
+
+// detectJVMFrameworks reads framework BOMs, parents and plugins from Maven and Gradle builds
+func detectJVMFrameworks(path string) []models.Framework {
+	var build string
+	for _, file := range []string{"pom.xml", "build.gradle.kts", "build.gradle", "gradle.properties", filepath.Join("gradle", "libs.versions.toml")} {
+		build += readFile(filepath.Join(path, file)) + "\n"
+	}
+
+	jvmFrameworks := []struct {
+		name     string
+		marker   *regexp.Regexp
+		versions []*regexp.Regexp
+	}{
+		{
+			name:   "spring-boot",
+			marker: regexp.MustCompile(`org\.springframework\.boot`),
+			versions: []*regexp.Regexp{
+				regexp.MustCompile(`<artifactId>spring-boot-(?:starter-parent|dependencies)</artifactId>\s*<version>([^<$]+)</version>`),
+				regexp.MustCompile(`id\s*\(?\s*["']org\.springframework\.boot["']\s*\)?\s*version\s*["']([^"']+)["']`),
+				regexp.MustCompile(`spring-boot-dependencies:([\d.]+)`),
+				regexp.MustCompile(`(?m)^spring-?[bB]oot(?:-v|V)ersion\s*=\s*"?([\d.]+)`),
+			},
+		},
+		{
+			name:   "quarkus",
+			marker: regexp.MustCompile(`io\.quarkus`),
+			versions: []*regexp.Regexp{
+				regexp.MustCompile(`<quarkus(?:\.platform)?\.version>([^<]+)</quarkus`),
+				regexp.MustCompile(`(?m)^quarkusPlatformVersion\s*=\s*([\d.]+)`),
+				regexp.MustCompile(`quarkus-bom:([\d.]+)`),
+			},
+		},
+		{
+			name:   "micronaut",
+			marker: regexp.MustCompile(`io\.micronaut`),
+			versions: []*regexp.Regexp{
+				regexp.MustCompile(`<artifactId>micronaut-parent</artifactId>\s*<version>([^<]+)</version>`),
+				regexp.MustCompile(`<micronaut\.version>([^<]+)</micronaut\.version>`),
+				regexp.MustCompile(`(?m)^micronautVersion\s*=\s*([\d.]+)`),
+			},
+		},
+	}
+
+	var frameworks []models.Framework
+	for _, fw := range jvmFrameworks {
+		if !fw.marker.MatchString(build) {
+			continue
+		}
+		framework := models.Framework{Name: fw.name}
+		for _, re := range fw.versions {
+			if match := re.FindStringSubmatch(build); match != nil {
+				framework.Version = strings.TrimSpace(match[1])
+				break
+			}
+		}
+		frameworks = append(frameworks, framework)
+	}
+	return frameworks
+}
+
+// detectDotNetFrameworks recognises ASP.NET Core from the web SDKs; it ships with the runtime
+func detectDotNetFrameworks(path string) []models.Framework {
+	webSDK := regexp.MustCompile(`Sdk="Microsoft\.NET\.Sdk\.(?:Web|BlazorWebAssembly|Razor)"|Microsoft\.AspNetCore\.App`)
+	for _, pattern := range []string{"*.csproj", "*/*.csproj", "*/*/*.csproj"} {
+		for _, file := range globFiles(path, pattern) {
+			if webSDK.MatchString(readFile(file)) {
+				return []models.Framework{{Name: "aspnetcore", Version: detectDotNetVersion(path)}}
+			}
+		}
+	}
+	return nil
+}
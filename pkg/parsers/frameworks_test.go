@@ -0,0 +1,191 @@
+package parsers
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+func TestDetectFrameworks(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		files    map[string]string
+		expected []models.Framework
+	}{
+		{
+			name:     "Django from poetry.lock",
+			language: "python",
+			files: map[string]string{
+				"pyproject.toml": "[tool.poetry.dependencies]\npython = \"^3.12\"\nDjango = \"^5.0\"\n",
+				"poetry.lock":    "[[package]]\nname = \"django\"\nversion = \"5.0.3\"\n\n[[package]]\nname = \"flask\"\nversion = \"3.0.2\"\n",
+			},
+			expected: []models.Framework{{Name: "django", Version: "5.0.3"}},
+		},
+		{
+			name:     "FastAPI from PEP 621 dependencies",
+			language: "python",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"api\"\ndescription = \"A flask replacement\"\ndependencies = [\n  \"fastapi[standard]>=0.110.0\",\n  \"uvicorn\",\n]\n",
+			},
+			expected: []models.Framework{{Name: "fastapi", Version: "0.110.0"}},
+		},
+		{
+			name:     "Keywords and tool settings are not dependencies",
+			language: "python",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"api\"\nkeywords = [\"flask\", \"django\"]\ndependencies = [\"fastapi>=0.110.0\"]\n\n[project.optional-dependencies]\ndev = [\n  \"pytest>=8.0\",\n]\n\n[tool.ruff.lint.isort]\nknown-first-party = [\"flask\"]\n",
+			},
+			expected: []models.Framework{{Name: "fastapi", Version: "0.110.0"}},
+		},
+		{
+			name:     "Flask from a Poetry group",
+			language: "python",
+			files: map[string]string{
+				"pyproject.toml": "[tool.poetry]\nname = \"api\"\npackages = [{ include = \"django\" }]\n\n[tool.poetry.group.web.dependencies]\nflask = \"^3.0.2\"\n",
+			},
+			expected: []models.Framework{{Name: "flask", Version: "3.0.2"}},
+		},
+		{
+			name:     "Flask from requirements.txt",
+			language: "python",
+			files:    map[string]string{"requirements.txt": "Flask==3.0.2\ngunicorn\n"},
+			expected: []models.Framework{{Name: "flask", Version: "3.0.2"}},
+		},
+		{
+			name:     "Next.js and React from package-lock.json",
+			language: "typescript",
+			files: map[string]string{
+				"package.json":      `{"dependencies": {"next": "^14.1.0", "react": "^18.2.0"}}`,
+				"package-lock.json": `{"packages": {"": {}, "node_modules/next": {"version": "14.1.4"}, "node_modules/react": {"version": "18.2.0"}, "node_modules/vite": {"version": "5.0.0"}}}`,
+			},
+			expected: []models.Framework{{Name: "nextjs", Version: "14.1.4"}, {Name: "react", Version: "18.2.0"}},
+		},
+		{
+			name:     "NestJS from yarn.lock",
+			language: "node",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"@nestjs/core": "^10.0.0"}}`,
+				"yarn.lock":    "\"@nestjs/core@^10.0.0\":\n  version \"10.3.3\"\n",
+			},
+			expected: []models.Framework{{Name: "nestjs", Version: "10.3.3"}},
+		},
+		{
+			name:     "Vite from pnpm-lock.yaml",
+			language: "node",
+			files: map[string]string{
+				"package.json":   `{"devDependencies": {"vite": "^5.1.0"}}`,
+				"pnpm-lock.yaml": "importers:\n  .:\n    devDependencies:\n      vite:\n        specifier: ^5.1.0\n        version: 5.1.6(@types/node@20.11.0)\n",
+			},
+			expected: []models.Framework{{Name: "vite", Version: "5.1.6"}},
+		},
+		{
+			name:     "Spring Boot parent",
+			language: "java",
+			files: map[string]string{
+				"pom.xml": "<project><parent><groupId>org.springframework.boot</groupId><artifactId>spring-boot-starter-parent</artifactId>\n<version>3.2.4</version></parent></project>",
+			},
+			expected: []models.Framework{{Name: "spring-boot", Version: "3.2.4"}},
+		},
+		{
+			name:     "Spring Boot Gradle plugin",
+			language: "kotlin",
+			files: map[string]string{
+				"build.gradle.kts": "plugins {\n  id(\"org.springframework.boot\") version \"3.3.0\"\n  kotlin(\"jvm\") version \"1.9.24\"\n}\n",
+			},
+			expected: []models.Framework{{Name: "spring-boot", Version: "3.3.0"}},
+		},
+		{
+			name:     "Quarkus from gradle.properties",
+			language: "java",
+			files: map[string]string{
+				"build.gradle":      "plugins {\n  id 'io.quarkus'\n}\n",
+				"gradle.properties": "quarkusPlatformVersion=3.8.3\n",
+			},
+			expected: []models.Framework{{Name: "quarkus", Version: "3.8.3"}},
+		},
+		{
+			name:     "Micronaut parent",
+			language: "java",
+			files: map[string]string{
+				"pom.xml": "<project><parent><groupId>io.micronaut.platform</groupId><artifactId>micronaut-parent</artifactId>\n<version>4.3.6</version></parent></project>",
+			},
+			expected: []models.Framework{{Name: "micronaut", Version: "4.3.6"}},
+		},
+		{
+			name:     "Rails from Gemfile.lock",
+			language: "ruby",
+			files: map[string]string{
+				"Gemfile":      "source 'https://rubygems.org'\ngem 'rails', '~> 7.1'\n",
+				"Gemfile.lock": "GEM\n  specs:\n    rails (7.1.3)\n      actionpack (= 7.1.3)\n    sinatra (4.0.0)\n",
+			},
+			expected: []models.Framework{{Name: "rails", Version: "7.1.3"}},
+		},
+		{
+			name:     "Sinatra without lockfile",
+			language: "ruby",
+			files:    map[string]string{"Gemfile": "gem \"sinatra\"\n"},
+			expected: []models.Framework{{Name: "sinatra", Version: ""}},
+		},
+		{
+			name:     "Laravel from composer.lock",
+			language: "php",
+			files: map[string]string{
+				"composer.json": `{"require": {"php": "^8.2", "laravel/framework": "^11.0"}}`,
+				"composer.lock": `{"packages": [{"name": "laravel/framework", "version": "v11.1.0"}]}`,
+			},
+			expected: []models.Framework{{Name: "laravel", Version: "11.1.0"}},
+		},
+		{
+			name:     "Symfony from composer.json",
+			language: "php",
+			files:    map[string]string{"composer.json": `{"require": {"symfony/framework-bundle": "7.0.*"}}`},
+			expected: []models.Framework{{Name: "symfony", Version: "7.0"}},
+		},
+		{
+			name:     "ASP.NET Core web SDK",
+			language: "csharp",
+			files: map[string]string{
+				"Api/Api.csproj": "<Project Sdk=\"Microsoft.NET.Sdk.Web\"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>",
+			},
+			expected: []models.Framework{{Name: "aspnetcore", Version: "8"}},
+		},
+		{
+			name:     "Gin with major version suffix",
+			language: "go",
+			files: map[string]string{
+				"go.mod": "module example.com/api\n\ngo 1.22\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.9.1\n\tgithub.com/labstack/echo/v4 v4.11.4\n)\n",
+			},
+			expected: []models.Framework{{Name: "gin", Version: "1.9.1"}, {Name: "echo", Version: "4.11.4"}},
+		},
+		{
+			name:     "Phoenix from mix.lock",
+			language: "elixir",
+			files: map[string]string{
+				"mix.exs":  "defp deps do\n  [{:phoenix, \"~> 1.7.10\"}]\nend\n",
+				"mix.lock": "%{\n  \"phoenix\": {:hex, :phoenix, \"1.7.12\", \"abc\", [:mix], [], \"hexpm\", \"def\"},\n}\n",
+			},
+			expected: []models.Framework{{Name: "phoenix", Version: "1.7.12"}},
+		},
+		{
+			name:     "No framework",
+			language: "rust",
+			files:    map[string]string{"Cargo.toml": "[package]\nname = \"cli\"\n"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			result := DetectFrameworks(tmpDir, tt.language)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package parsers
+
+import (
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/version"
+)
+
+// stackageGHCVersions maps Stackage LTS major versions to the GHC release they ship
+var stackageGHCVersions = map[int]string{
+	24: "9.10",
+	23: "9.8",
+	22: "9.6",
+	21: "9.4",
+	20: "9.2",
+	19: "9.0",
+	18: "8.10",
+	17: "8.10",
+	16: "8.8",
+	15: "8.6",
+	14: "8.6",
+}
+
+// stackageNightlyGHCVersion is the GHC release used by Stackage nightly snapshots
+const stackageNightlyGHCVersion = "9.12"
+
+// Haskell detection
+func detectHaskellVersion(path string) string {
+	// default-language in a .cabal file, e.g. GHC2021 or Haskell2010
+	re := regexp.MustCompile(`(?mi)^\s*default-language:\s*(\S+)`)
+	for _, file := range globFiles(path, "*.cabal") {
+		if match := re.FindStringSubmatch(readFile(file)); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// detectGHCVersion detects the GHC compiler version from Stack or Cabal configuration
+func detectGHCVersion(path string) string {
+	if content := readFile(filepath.Join(path, "stack.yaml")); content != "" {
+		re := regexp.MustCompile(`(?m)^(?:resolver|snapshot):\s*(\S+)`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			if version := ghcVersionForResolver(match[1]); version != "" {
+				return version
+			}
+		}
+	}
+
+	if content := readFile(filepath.Join(path, "cabal.project")); content != "" {
+		re := regexp.MustCompile(`(?m)^with-compiler:\s*ghc-([\d.]+)`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+
+	// tested-with lists every supported compiler; CI should use the newest
+	re := regexp.MustCompile(`(?i)GHC\s*==\s*([\d.]+)`)
+	testedWith := regexp.MustCompile(`(?mi)^\s*tested-with:(.*(?:\n\s+.*)*)`)
+	var newest string
+	for _, file := range globFiles(path, "*.cabal") {
+		if field := testedWith.FindStringSubmatch(readFile(file)); field != nil {
+			for _, match := range re.FindAllStringSubmatch(field[1], -1) {
+				if newest == "" || version.Compare(match[1], newest) > 0 {
+					newest = match[1]
+				}
+			}
+		}
+	}
+	return newest
+}
+
+// ghcVersionForResolver maps a Stack resolver such as "lts-22.7" or "ghc-9.4.7" to a GHC version
+func ghcVersionForResolver(resolver string) string {
+	switch {
+	case strings.HasPrefix(resolver, "ghc-"):
+		return strings.TrimPrefix(resolver, "ghc-")
+	case strings.HasPrefix(resolver, "nightly"):
+		return stackageNightlyGHCVersion
+	case strings.HasPrefix(resolver, "lts-"):
+		major, err := strconv.Atoi(strings.SplitN(strings.TrimPrefix(resolver, "lts-"), ".", 2)[0])
+		if err != nil {
+			return ""
+		}
+		// An LTS newer than the table ships at least the newest GHC we know of;
+		// older ones predate the GHC releases we build images for
+		newest := slices.Max(slices.Collect(maps.Keys(stackageGHCVersions)))
+		return stackageGHCVersions[min(major, newest)]
+	}
+	return ""
+}
+
+func detectHaskellBuildTool(path string) string {
+	if fileExists(path, "stack.yaml") {
+		return "stack"
+	}
+	return "cabal"
+}
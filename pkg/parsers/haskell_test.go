@@ -0,0 +1,39 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectGHCVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{"Stack LTS resolver", map[string]string{"stack.yaml": "resolver: lts-22.7\npackages:\n- .\n"}, "9.6"},
+		{"Stack LTS 24", map[string]string{"stack.yaml": "resolver: lts-24.11\n"}, "9.10"},
+		{"LTS newer than the table", map[string]string{"stack.yaml": "resolver: lts-30.1\n"}, "9.10"},
+		{"Stack snapshot key", map[string]string{"stack.yaml": "snapshot: lts-21.25\n"}, "9.4"},
+		{"Stack GHC resolver", map[string]string{"stack.yaml": "resolver: ghc-9.4.7\n"}, "9.4.7"},
+		{"Stack nightly", map[string]string{"stack.yaml": "resolver: nightly-2024-01-20\n"}, stackageNightlyGHCVersion},
+		{"cabal.project with-compiler", map[string]string{"cabal.project": "packages: .\nwith-compiler: ghc-9.6.3\n"}, "9.6.3"},
+		{
+			"Newest tested-with compiler",
+			map[string]string{"app.cabal": "name: app\ntested-with: GHC == 9.2.8\n           , GHC == 9.6.3\n           , GHC == 9.4.7\n"},
+			"9.6.3",
+		},
+		{"LTS older than the table", map[string]string{"stack.yaml": "resolver: lts-2.22\n"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectRuntimeVersion(tmpDir, "haskell", ""); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DetectIaCVersion detects the version of an infrastructure-as-code tool
+func DetectIaCVersion(path, tool string) string {
+	switch tool {
+	case "terraform":
+		return detectTerraformVersion(path, ".terraform-version", "terraform")
+	case "opentofu":
+		return detectTerraformVersion(path, ".opentofu-version", "opentofu")
+	case "pulumi":
+		return detectPulumiVersion(path)
+	case "helm":
+		return detectHelmVersion(path)
+	default:
+		return ""
+	}
+}
+
+// Terraform and OpenTofu detection
+func detectTerraformVersion(path, versionFile, tool string) string {
+	// tfenv/tofuenv pin the exact CLI version
+	if content := readFile(filepath.Join(path, versionFile)); content != "" {
+		return strings.TrimPrefix(strings.TrimSpace(content), "v")
+	}
+	if version := toolVersionsEntry(path, tool); version != "" {
+		return version
+	}
+
+	// required_version = ">= 1.5.0" inside a terraform block
+	re := regexp.MustCompile(`required_version\s*=\s*"[<>=~!\s]*(\d+\.\d+(?:\.\d+)?)`)
+	for _, pattern := range []string{"*.tf", "*.tofu", "*/*.tf", "*/*.tofu"} {
+		for _, file := range globFiles(path, pattern) {
+			if match := re.FindStringSubmatch(readFile(file)); match != nil {
+				return match[1]
+			}
+		}
+	}
+	return ""
+}
+
+// PulumiProject represents the parts of Pulumi.yaml used for detection
+type PulumiProject struct {
+	Runtime interface{} `yaml:"runtime"`
+}
+
+// DetectPulumiLanguage maps the Pulumi.yaml runtime to a configured language name
+func DetectPulumiLanguage(path string) string {
+	content := readFile(filepath.Join(path, "Pulumi.yaml"))
+	if content == "" {
+		content = readFile(filepath.Join(path, "Pulumi.yml"))
+	}
+	var project PulumiProject
+	if err := yaml.Unmarshal([]byte(content), &project); err != nil {
+		return ""
+	}
+
+	// runtime is either a name or a mapping with a name and options
+	var runtime string
+	switch value := project.Runtime.(type) {
+	case string:
+		runtime = value
+	case map[string]interface{}:
+		runtime, _ = value["name"].(string)
+	}
+
+	switch runtime {
+	case "nodejs":
+		if fileExists(path, "tsconfig.json") {
+			return "typescript"
+		}
+		return "node"
+	default:
+		return runtime
+	}
+}
+
+// Pulumi detection
+func detectPulumiVersion(path string) string {
+	if version := toolVersionsEntry(path, "pulumi"); version != "" {
+		return version
+	}
+
+	// The CLI major version follows the SDK the program depends on
+	sdkPatterns := map[string]string{
+		"package.json":     `"@pulumi/pulumi"\s*:\s*"[\^~>=\s]*(\d+\.\d+\.\d+)`,
+		"requirements.txt": `(?m)^pulumi\s*[>=~]=\s*(\d+\.\d+\.\d+)`,
+		"pyproject.toml":   `pulumi\s*[>=~]=\s*(\d+\.\d+\.\d+)`,
+		"go.mod":           `github\.com/pulumi/pulumi/sdk/v\d+\s+v(\d+\.\d+\.\d+)`,
+	}
+	for _, file := range []string{"package.json", "requirements.txt", "pyproject.toml", "go.mod"} {
+		if content := readFile(filepath.Join(path, file)); content != "" {
+			if match := regexp.MustCompile(sdkPatterns[file]).FindStringSubmatch(content); match != nil {
+				return match[1]
+			}
+		}
+	}
+	return ""
+}
+
+// Helm detection
+func detectHelmVersion(path string) string {
+	if version := toolVersionsEntry(path, "helm"); version != "" {
+		return version
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectIaCVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     string
+		files    map[string]string
+		expected string
+	}{
+		{"Terraform required_version", "terraform", map[string]string{"main.tf": "terraform {\n  required_version = \">= 1.5.0\"\n}\n"}, "1.5.0"},
+		{"Terraform pessimistic constraint", "terraform", map[string]string{"infra/versions.tf": "terraform {\n  required_version = \"~> 1.6\"\n}\n"}, "1.6"},
+		{".terraform-version", "terraform", map[string]string{"main.tf": "terraform {\n  required_version = \">= 1.0\"\n}\n", ".terraform-version": "1.7.5\n"}, "1.7.5"},
+		{".opentofu-version", "opentofu", map[string]string{"main.tf": "", ".opentofu-version": "1.8.1"}, "1.8.1"},
+		{"Pulumi Node.js SDK", "pulumi", map[string]string{"Pulumi.yaml": "name: infra\nruntime: nodejs\n", "package.json": `{"dependencies": {"@pulumi/pulumi": "^3.113.0"}}`}, "3.113.0"},
+		{"Pulumi Go SDK", "pulumi", map[string]string{"Pulumi.yaml": "name: infra\nruntime: go\n", "go.mod": "module infra\n\nrequire github.com/pulumi/pulumi/sdk/v3 v3.120.0\n"}, "3.120.0"},
+		{"Helm chart", "helm", map[string]string{"Chart.yaml": "apiVersion: v2\nname: app\n"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectIaCVersion(tmpDir, tt.tool); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDetectPulumiLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{"nodejs", map[string]string{"Pulumi.yaml": "name: infra\nruntime: nodejs\n"}, "node"},
+		{"nodejs with TypeScript", map[string]string{"Pulumi.yaml": "name: infra\nruntime: nodejs\n", "tsconfig.json": "{}"}, "typescript"},
+		{"python with options", map[string]string{"Pulumi.yaml": "name: infra\nruntime:\n  name: python\n  options:\n    virtualenv: venv\n"}, "python"},
+		{"go", map[string]string{"Pulumi.yml": "name: infra\nruntime: go\n"}, "go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectPulumiLanguage(tmpDir); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ImageReference is a container image a repository file pulls
+type ImageReference struct {
+	Image string
+	File  string // Relative to the project path
+}
+
+// dockerfileFrom matches FROM instructions, capturing the image and an optional stage name
+var dockerfileFrom = regexp.MustCompile(`(?im)^\s*FROM\s+(?:--platform=\S+\s+)?(\S+)(?:\s+AS\s+(\S+))?`)
+
+// DetectImageReferences lists the base images of Dockerfiles and the service images
+// of Compose files. References to earlier build stages, scratch and images built
+// from unresolved build arguments are skipped.
+func DetectImageReferences(path string) []ImageReference {
+	var refs []ImageReference
+	for _, file := range dockerfiles(path) {
+		stages := make(map[string]bool)
+		for _, match := range dockerfileFrom.FindAllStringSubmatch(readFile(file), -1) {
+			image := match[1]
+			if !stages[strings.ToLower(image)] && image != "scratch" && !strings.Contains(image, "$") {
+				refs = append(refs, ImageReference{Image: image, File: relPath(path, file)})
+			}
+			if match[2] != "" {
+				stages[strings.ToLower(match[2])] = true
+			}
+		}
+	}
+
+	for _, file := range composeFiles(path) {
+		var compose struct {
+			Services map[string]struct {
+				Image string `yaml:"image"`
+			} `yaml:"services"`
+		}
+		if err := yaml.Unmarshal([]byte(readFile(file)), &compose); err != nil {
+			continue
+		}
+		names := make([]string, 0, len(compose.Services))
+		for name := range compose.Services {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			if image := interpolateDefaults(compose.Services[name].Image); image != "" && !strings.Contains(image, "$") {
+				refs = append(refs, ImageReference{Image: image, File: relPath(path, file)})
+			}
+		}
+	}
+	return refs
+}
+
+// dockerfiles lists the Dockerfiles of a project, e.g. Dockerfile, Dockerfile.dev and api.Dockerfile
+func dockerfiles(path string) []string {
+	var files []string
+	for _, pattern := range []string{"Dockerfile", "Dockerfile.*", "*.Dockerfile", "*.dockerfile"} {
+		files = append(files, globFiles(path, pattern)...)
+	}
+	return files
+}
+
+// relPath returns file relative to path with forward slashes
+func relPath(path, file string) string {
+	rel, err := filepath.Rel(path, file)
+	if err != nil {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
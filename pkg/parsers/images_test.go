@@ -0,0 +1,54 @@
+package parsers
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestDetectImageReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected []ImageReference
+	}{
+		{
+			name: "Multi-stage Dockerfile",
+			files: map[string]string{
+				"Dockerfile": "ARG BASE=python:3.12\nFROM --platform=$BUILDPLATFORM golang:1.22-alpine AS build\nRUN go build\nFROM ${BASE}\nFROM build AS test\nfrom gcr.io/distroless/static:nonroot\nCOPY --from=build /app /app\n",
+			},
+			expected: []ImageReference{
+				{Image: "golang:1.22-alpine", File: "Dockerfile"},
+				{Image: "gcr.io/distroless/static:nonroot", File: "Dockerfile"},
+			},
+		},
+		{
+			name: "Scratch image and Compose services",
+			files: map[string]string{
+				"api.Dockerfile":     "FROM scratch\n",
+				"docker-compose.yml": "services:\n  app:\n    build: .\n  db:\n    image: ${DB_IMAGE:-postgres:16}\n  cache:\n    image: redis:7\n",
+			},
+			expected: []ImageReference{
+				{Image: "redis:7", File: "docker-compose.yml"},
+				{Image: "postgres:16", File: "docker-compose.yml"},
+			},
+		},
+		{
+			name:     "No images",
+			files:    map[string]string{"main.go": "package main\n"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			result := DetectImageReferences(tmpDir)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
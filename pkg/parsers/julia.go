@@ -0,0 +1,31 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+)
+
+// Julia detection
+func detectJuliaVersion(path string) string {
+	// Manifest.toml records the Julia version that resolved the environment
+	for _, file := range []string{"Manifest.toml", "JuliaManifest.toml"} {
+		if content := readFile(filepath.Join(path, file)); content != "" {
+			re := regexp.MustCompile(`(?m)^julia_version\s*=\s*"([^"]+)"`)
+			if match := re.FindStringSubmatch(content); match != nil {
+				return match[1]
+			}
+		}
+	}
+
+	// Project.toml [compat] julia = "1.9"
+	for _, file := range []string{"Project.toml", "JuliaProject.toml"} {
+		if content := readFile(filepath.Join(path, file)); content != "" {
+			re := regexp.MustCompile(`(?m)^julia\s*=\s*"[\^~=\s]*(\d+\.\d+(?:\.\d+)?)`)
+			if match := re.FindStringSubmatch(content); match != nil {
+				return match[1]
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,29 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectJuliaVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{"Manifest.toml", map[string]string{"Project.toml": "name = \"Analysis\"\n", "Manifest.toml": "# This file is machine-generated\njulia_version = \"1.10.2\"\nmanifest_format = \"2.0\"\n"}, "1.10.2"},
+		{"Project.toml compat", map[string]string{"Project.toml": "name = \"Analysis\"\n\n[compat]\nDataFrames = \"1.6\"\njulia = \"1.9\"\n"}, "1.9"},
+		{"No version", map[string]string{"Project.toml": "name = \"Analysis\"\n"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectVersion(tmpDir, "julia", "Pkg"); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
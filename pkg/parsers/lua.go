@@ -0,0 +1,34 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+)
+
+// Lua detection
+func detectLuaVersion(path string) string {
+	if version := toolVersionsEntry(path, "lua"); version != "" {
+		return version
+	}
+
+	// .luarc.json: "runtime.version": "Lua 5.4" or "runtime": {"version": "Lua 5.4"}
+	for _, file := range []string{".luarc.json", ".luarc.jsonc"} {
+		if content := readFile(filepath.Join(path, file)); content != "" {
+			re := regexp.MustCompile(`"(?:runtime\.)?version"\s*:\s*"Lua\s*(\d+\.\d+)"`)
+			if match := re.FindStringSubmatch(content); match != nil {
+				return match[1]
+			}
+		}
+	}
+
+	// rockspec: dependencies = { "lua >= 5.1" }
+	re := regexp.MustCompile(`["']lua\s*[<>=~]*\s*(\d+\.\d+)`)
+	for _, pattern := range []string{"*.rockspec", "rockspecs/*.rockspec"} {
+		for _, file := range globFiles(path, pattern) {
+			if match := re.FindStringSubmatch(readFile(file)); match != nil {
+				return match[1]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectLuaVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{"rockspec dependency", map[string]string{"app-1.0-1.rockspec": "package = \"app\"\ndependencies = {\n  \"lua >= 5.1, < 5.5\",\n}\n"}, "5.1"},
+		{".luarc.json dotted key", map[string]string{".luarc.json": `{"runtime.version": "Lua 5.4"}`}, "5.4"},
+		{".luarc.json nested", map[string]string{".luarc.json": `{"runtime": {"version": "Lua 5.3"}}`}, "5.3"},
+		{"LuaJIT runtime", map[string]string{".luarc.json": `{"runtime.version": "LuaJIT"}`}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectVersion(tmpDir, "lua", "luarocks"); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package parsers
+
+import (
+	"slices"
+)
+
+// nativePackages lists packages with compiled extensions that ship glibc-only binaries
+// or need a compiler toolchain on musl (Alpine), per package ecosystem
+var nativePackages = map[string][]string{
+	"python": {
+		"numpy", "scipy", "pandas", "pyarrow", "psycopg2", "psycopg2-binary", "grpcio",
+		"lxml", "cryptography", "pillow", "mysqlclient", "uvloop", "torch", "tensorflow",
+	},
+	"node": {
+		"node-gyp", "sharp", "bcrypt", "canvas", "sqlite3", "better-sqlite3", "node-sass",
+		"argon2", "re2", "cpu-features",
+	},
+	"ruby": {
+		"nokogiri", "grpc", "sassc", "mini_racer", "libv8-node", "ffi", "google-protobuf",
+	},
+}
+
+// DetectNativeDependencies lists dependencies with native extensions that favour a
+// glibc-based image. Lockfiles are preferred since native packages are often transitive.
+func DetectNativeDependencies(path, language string) []string {
+	var ecosystem string
+	var packages map[string]string
+	switch language {
+	case "python":
+		ecosystem = "python"
+		if packages = pythonLockedPackages(path); len(packages) == 0 {
+			packages = pythonDependencies(path)
+		}
+	case "node", "javascript", "typescript":
+		ecosystem = "node"
+		if packages = nodeLockedPackages(path); len(packages) == 0 {
+			packages = nodeDependencies(path)
+		}
+	case "ruby":
+		ecosystem = "ruby"
+		if packages = rubyLockedPackages(path); len(packages) == 0 {
+			packages = rubyDependencies(path)
+		}
+	default:
+		return nil
+	}
+
+	var native []string
+	for _, name := range nativePackages[ecosystem] {
+		if _, ok := packages[name]; ok {
+			native = append(native, name)
+		}
+	}
+	slices.Sort(native)
+	return native
+}
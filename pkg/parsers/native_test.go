@@ -0,0 +1,83 @@
+package parsers
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestDetectNativeDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		files    map[string]string
+		expected []string
+	}{
+		{
+			name:     "Transitive package from poetry.lock",
+			language: "python",
+			files: map[string]string{
+				"pyproject.toml": "[tool.poetry.dependencies]\npasslib = \"^1.7\"\n",
+				"poetry.lock":    "[[package]]\nname = \"passlib\"\nversion = \"1.7.4\"\n\n[[package]]\nname = \"cryptography\"\nversion = \"42.0.5\"\n",
+			},
+			expected: []string{"cryptography"},
+		},
+		{
+			name:     "Manifest without lockfile",
+			language: "python",
+			files:    map[string]string{"requirements.txt": "psycopg2==2.9.9\ngrpcio>=1.62\n"},
+			expected: []string{"grpcio", "psycopg2"},
+		},
+		{
+			name:     "Nested node-gyp in package-lock.json",
+			language: "typescript",
+			files: map[string]string{
+				"package.json":      `{"dependencies": {"bcrypt": "^5.1.0"}}`,
+				"package-lock.json": `{"packages": {"node_modules/bcrypt": {"version": "5.1.1"}, "node_modules/bcrypt/node_modules/node-gyp": {"version": "10.0.1"}}}`,
+			},
+			expected: []string{"bcrypt", "node-gyp"},
+		},
+		{
+			name:     "pnpm packages",
+			language: "node",
+			files: map[string]string{
+				"package.json":   `{"dependencies": {"next": "^14.1.0"}}`,
+				"pnpm-lock.yaml": "lockfileVersion: '9.0'\n\npackages:\n\n  next@14.1.4:\n    resolution: {}\n\n  sharp@0.33.2:\n    resolution: {}\n",
+			},
+			expected: []string{"sharp"},
+		},
+		{
+			name:     "yarn.lock",
+			language: "node",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"sqlite3": "^5.1.7"}}`,
+				"yarn.lock":    "sqlite3@^5.1.7:\n  version \"5.1.7\"\n",
+			},
+			expected: []string{"sqlite3"},
+		},
+		{
+			name:     "Gemfile.lock platform gems",
+			language: "ruby",
+			files:    map[string]string{"Gemfile": "gem 'rails'\n", "Gemfile.lock": "GEM\n  specs:\n    ffi (1.16.3)\n    nokogiri (1.16.3-x86_64-linux)\n"},
+			expected: []string{"ffi", "nokogiri"},
+		},
+		{
+			name:     "No native dependencies",
+			language: "node",
+			files:    map[string]string{"package.json": `{"dependencies": {"express": "^4.19.0"}}`},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			result := DetectNativeDependencies(tmpDir, tt.language)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
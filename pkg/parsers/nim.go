@@ -0,0 +1,20 @@
+package parsers
+
+import (
+	"regexp"
+)
+
+// Nim detection
+func detectNimVersion(path string) string {
+	if version := toolVersionsEntry(path, "nim"); version != "" {
+		return version
+	}
+	// requires "nim >= 2.0.0", possibly alongside other packages
+	re := regexp.MustCompile(`"nim\s*[<>=~^]*\s*(\d+\.\d+(?:\.\d+)?)`)
+	for _, file := range globFiles(path, "*.nimble") {
+		if match := re.FindStringSubmatch(readFile(file)); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
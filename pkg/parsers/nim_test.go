@@ -0,0 +1,29 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectNimVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{"requires nim", map[string]string{"app.nimble": "version = \"0.1.0\"\nrequires \"nim >= 2.0\"\n"}, "2.0"},
+		{"requires list", map[string]string{"app.nimble": "requires \"nim >= 1.6.14\", \"jester >= 0.6.0\"\n"}, "1.6.14"},
+		{"no requirement", map[string]string{"app.nimble": "version = \"0.1.0\"\n"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectVersion(tmpDir, "nim", "nimble"); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
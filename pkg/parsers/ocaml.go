@@ -0,0 +1,44 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+)
+
+// OCaml detection
+func detectOCamlVersion(path string) string {
+	// opam: "ocaml" {>= "4.14.0"}
+	opamRe := regexp.MustCompile(`"ocaml"\s*\{[^}]*?"(\d+\.\d+)`)
+	for _, file := range globFiles(path, "*.opam") {
+		if match := opamRe.FindStringSubmatch(readFile(file)); match != nil {
+			return match[1]
+		}
+	}
+
+	// dune-project: (depends (ocaml (>= 4.14)))
+	if content := readFile(filepath.Join(path, "dune-project")); content != "" {
+		re := regexp.MustCompile(`\(ocaml\s*\([<>=]*\s*"?(\d+\.\d+)`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// detectDuneVersion reads the dune language version, e.g. (lang dune 3.11)
+func detectDuneVersion(path string) string {
+	if content := readFile(filepath.Join(path, "dune-project")); content != "" {
+		re := regexp.MustCompile(`\(lang\s+dune\s+([\d.]+)\)`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+func detectOCamlBuildTool(path string) string {
+	if fileExists(path, "dune-project") {
+		return "dune"
+	}
+	return "opam"
+}
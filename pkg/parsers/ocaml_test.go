@@ -0,0 +1,62 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectOCamlVersions(t *testing.T) {
+	tests := []struct {
+		name            string
+		files           map[string]string
+		expectedBuild   string
+		expectedVersion string
+		expectedDune    string
+	}{
+		{
+			name: "dune project with opam file",
+			files: map[string]string{
+				"dune-project": "(lang dune 3.11)\n(name app)\n",
+				"app.opam":     "opam-version: \"2.0\"\ndepends: [\n  \"ocaml\" {>= \"4.14.0\"}\n  \"dune\" {>= \"3.11\"}\n]\n",
+			},
+			expectedBuild:   "dune",
+			expectedVersion: "4.14",
+			expectedDune:    "3.11",
+		},
+		{
+			name: "dune-project depends",
+			files: map[string]string{
+				"dune-project": "(lang dune 3.0)\n(package (name app) (depends (ocaml (>= 5.1))))\n",
+			},
+			expectedBuild:   "dune",
+			expectedVersion: "5.1",
+			expectedDune:    "3.0",
+		},
+		{
+			name: "opam only",
+			files: map[string]string{
+				"lib.opam": "depends: [ \"ocaml\" {>= \"4.08\" & < \"5.0\"} ]\n",
+			},
+			expectedBuild:   "opam",
+			expectedVersion: "4.08",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			buildTool := DetectBuildTool(tmpDir, "ocaml")
+			if buildTool != tt.expectedBuild {
+				t.Errorf("Expected build tool %q, got %q", tt.expectedBuild, buildTool)
+			}
+			if result := DetectVersion(tmpDir, "ocaml", buildTool); result != tt.expectedVersion {
+				t.Errorf("Expected OCaml version %q, got %q", tt.expectedVersion, result)
+			}
+			if _, version := DetectToolchain(tmpDir, "ocaml", buildTool); version != tt.expectedDune {
+				t.Errorf("Expected dune version %q, got %q", tt.expectedDune, version)
+			}
+		})
+	}
+}
@@ -11,7 +11,8 @@ import (
 	"strings"
 )
 
-// DetectVersion detects the version for a given language
+// DetectVersion detects the language version for a given language.
+// For JVM languages this is the language level (e.g. Kotlin 1.9.22), not the JDK.
 func DetectVersion(path, language, buildTool string) string {
 	switch language {
 	case "python":
@@ -20,8 +21,10 @@ func DetectVersion(path, language, buildTool string) string {
 		return detectJavaVersion(path, buildTool)
 	case "kotlin":
 		return detectKotlinVersion(path, buildTool)
-	case "node", "javascript", "typescript":
+	case "node":
 		return detectNodeVersion(path)
+	case "typescript":
+		return detectTypeScriptVersion(path)
 	case "go":
 		return detectGoVersion(path)
 	case "rust":
@@ -30,17 +33,158 @@ func DetectVersion(path, language, buildTool string) string {
 		return detectRubyVersion(path)
 	case "php":
 		return detectPHPVersion(path)
-	case "dotnet", "csharp":
+	case "dotnet":
 		return detectDotNetVersion(path)
+	case "csharp":
+		return detectCSharpVersion(path)
 	case "swift":
 		return detectSwiftVersion(path)
 	case "scala":
 		return detectScalaVersion(path, buildTool)
+	case "elixir":
+		return detectElixirVersion(path)
+	case "erlang":
+		return detectOTPVersion(path)
+	case "dart", "flutter":
+		return detectDartVersion(path)
+	case "c", "cpp":
+		return detectCStandard(path, language)
+	case "haskell":
+		return detectHaskellVersion(path)
+	case "clojure":
+		return detectClojureVersion(path)
+	case "ocaml":
+		return detectOCamlVersion(path)
+	case "deno":
+		return detectDenoVersion(path)
+	case "bun":
+		return detectBunVersion(path)
+	case "zig":
+		return detectZigVersion(path)
+	case "nim":
+		return detectNimVersion(path)
+	case "crystal":
+		return detectCrystalVersion(path)
+	case "r":
+		return detectRVersion(path)
+	case "julia":
+		return detectJuliaVersion(path)
+	case "perl":
+		return detectPerlVersion(path)
+	case "lua":
+		return detectLuaVersion(path)
+	default:
+		return ""
+	}
+}
+
+// DetectRuntimeVersion detects the version of the runtime that executes a language,
+// e.g. the JVM for Kotlin and Scala or Node.js for TypeScript
+func DetectRuntimeVersion(path, language, buildTool string) string {
+	switch language {
+	case "java", "kotlin", "scala":
+		return detectJVMVersion(path, buildTool)
+	case "node", "javascript", "typescript":
+		return detectNodeVersion(path)
+	case "rust":
+		if channel := detectRustToolchain(path); channel != "" {
+			return channel
+		}
+		return detectRustVersion(path)
+	case "dotnet", "csharp":
+		return detectDotNetVersion(path)
+	case "elixir", "erlang":
+		return detectOTPVersion(path)
+	case "flutter":
+		return detectFlutterVersion(path)
+	case "c", "cpp":
+		// Only a compiler the build files select may carry a version, e.g. clang-17
+		_, version := DetectCompiler(path, language)
+		return version
+	case "haskell":
+		return detectGHCVersion(path)
+	case "clojure":
+		return detectClojureJVMVersion(path)
+	default:
+		return DetectVersion(path, language, buildTool)
+	}
+}
+
+// DetectToolchain detects an explicitly pinned compiler or SDK.
+// It returns empty strings when the project does not pin one.
+func DetectToolchain(path, language, buildTool string) (name, version string) {
+	switch language {
+	case "java", "kotlin", "scala", "clojure":
+		if version := detectJDKToolchain(path, buildTool); version != "" {
+			return "jdk", version
+		}
+	case "go":
+		if version := detectGoToolchain(path); version != "" {
+			return "go", version
+		}
+	case "rust":
+		if channel := detectRustToolchain(path); channel != "" {
+			return "rustc", channel
+		}
+	case "dotnet", "csharp":
+		if version := detectDotNetSDKVersion(path); version != "" {
+			return "dotnet-sdk", version
+		}
+	case "swift":
+		if content := readFile(filepath.Join(path, ".swift-version")); content != "" {
+			return "swift", strings.TrimSpace(content)
+		}
+	case "c", "cpp":
+		if version := detectCBuildSystemVersion(path, buildTool); version != "" {
+			return buildTool, version
+		}
+	case "ocaml":
+		if version := detectDuneVersion(path); version != "" {
+			return "dune", version
+		}
+	}
+	return "", ""
+}
+
+// DetectPackageManager detects a dependency manager that is separate from the build tool
+func DetectPackageManager(path, language string) string {
+	switch language {
+	case "c", "cpp":
+		return detectCPackageManager(path)
 	default:
 		return ""
 	}
 }
 
+// RefineLanguage narrows a detected language using project file contents,
+// e.g. a Dart project that depends on the Flutter SDK is a Flutter project
+func RefineLanguage(path, language string) string {
+	switch language {
+	case "dart":
+		if isFlutterProject(path) {
+			return "flutter"
+		}
+	case "c":
+		if isCppProject(path) {
+			return "cpp"
+		}
+	case "java":
+		if isKotlinProject(path) {
+			return "kotlin"
+		}
+	case "node", "javascript", "typescript":
+		if isDenoProject(path) {
+			return "deno"
+		}
+		if isBunProject(path) {
+			return "bun"
+		}
+	case "bash", "sh", "zsh":
+		return detectShellInterpreter(path, language)
+	}
+	return language
+}
+
 // DetectBuildTool detects the build tool for a given language
 func DetectBuildTool(path, language string) string {
 	switch language {
@@ -66,6 +210,40 @@ func DetectBuildTool(path, language string) string {
 		return "swift"
 	case "scala":
 		return detectScalaBuildTool(path)
+	case "elixir":
+		return "mix"
+	case "erlang":
+		return detectErlangBuildTool(path)
+	case "dart":
+		return "dart"
+	case "flutter":
+		return "flutter"
+	case "c", "cpp":
+		return detectCBuildTool(path)
+	case "haskell":
+		return detectHaskellBuildTool(path)
+	case "clojure":
+		return detectClojureBuildTool(path)
+	case "ocaml":
+		return detectOCamlBuildTool(path)
+	case "deno":
+		return "deno"
+	case "bun":
+		return "bun"
+	case "zig":
+		return "zig"
+	case "nim":
+		return "nimble"
+	case "crystal":
+		return "shards"
+	case "r":
+		return detectRBuildTool(path)
+	case "julia":
+		return "Pkg"
+	case "perl":
+		return detectPerlBuildTool(path)
+	case "lua":
+		return "luarocks"
 	default:
 		return ""
 	}
@@ -134,7 +312,11 @@ func detectPythonBuildTool(path string) string {
 func detectJavaVersion(path, buildTool string) string {
 	if buildTool == "maven" {
 		if content := readFile(filepath.Join(path, "pom.xml")); content != "" {
-			re := regexp.MustCompile(`<maven\.compiler\.source>(\d+)</maven\.compiler\.source>`)
+			re := regexp.MustCompile(`<maven\.compiler\.release>(\d+)</maven\.compiler\.release>`)
+			if match := re.FindStringSubmatch(content); match != nil {
+				return match[1]
+			}
+			re = regexp.MustCompile(`<maven\.compiler\.source>(\d+)</maven\.compiler\.source>`)
 			if match := re.FindStringSubmatch(content); match != nil {
 				return match[1]
 			}
@@ -164,6 +346,69 @@ func detectJavaVersion(path, buildTool string) string {
 	return ""
 }
 
+// JVM detection shared by Java, Kotlin and Scala
+func detectJVMVersion(path, buildTool string) string {
+	if version := detectJDKToolchain(path, buildTool); version != "" {
+		return jdkMajorVersion(version)
+	}
+
+	// Kotlin compiles against a JVM target that must be available at runtime
+	for _, file := range []string{"build.gradle.kts", "build.gradle"} {
+		if content := readFile(filepath.Join(path, file)); content != "" {
+			re := regexp.MustCompile(`jvmTarget\s*(?:=|\.set\()\s*["']?(?:JvmTarget\.JVM_)?(\d+(?:[._]\d+)?)`)
+			if match := re.FindStringSubmatch(content); match != nil {
+				// JvmTarget.JVM_1_8 is the constant for "1.8"
+				return jdkMajorVersion(strings.ReplaceAll(match[1], "_", "."))
+			}
+		}
+	}
+
+	// Fall back to the Java language level configured for the build
+	switch buildTool {
+	case "maven", "gradle":
+		return detectJavaVersion(path, buildTool)
+	}
+	return detectJavaVersion(path, detectJavaBuildTool(path))
+}
+
+// detectJDKToolchain reads an explicitly pinned JDK from version manager files
+// or a Gradle toolchain block
+func detectJDKToolchain(path, buildTool string) string {
+	if content := readFile(filepath.Join(path, ".java-version")); content != "" {
+		return strings.TrimSpace(content)
+	}
+
+	if content := readFile(filepath.Join(path, ".sdkmanrc")); content != "" {
+		re := regexp.MustCompile(`(?m)^java\s*=\s*(\S+)`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+
+	if buildTool == "gradle" {
+		for _, file := range []string{"build.gradle.kts", "build.gradle"} {
+			if content := readFile(filepath.Join(path, file)); content != "" {
+				re := regexp.MustCompile(`(?:JavaLanguageVersion\.of|jvmToolchain)\(\s*(\d+)\s*\)`)
+				if match := re.FindStringSubmatch(content); match != nil {
+					return match[1]
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// jdkMajorVersion reduces a JDK version such as "21.0.2-tem" or "1.8" to its major version
+func jdkMajorVersion(version string) string {
+	version = strings.TrimPrefix(version, "1.")
+	re := regexp.MustCompile(`^(\d+)`)
+	if match := re.FindStringSubmatch(version); match != nil {
+		return match[1]
+	}
+	return version
+}
+
 func detectJavaBuildTool(path string) string {
 	if fileExists(path, "pom.xml") {
 		return "maven"
@@ -190,6 +435,19 @@ func detectKotlinVersion(path, buildTool string) string {
 	return ""
 }
 
+// isKotlinProject checks whether a JVM build applies the Kotlin plugin or keeps
+// its sources in src/main/kotlin
+func isKotlinProject(path string) bool {
+	for _, file := range []string{"build.gradle.kts", "build.gradle", "pom.xml"} {
+		content := readFile(filepath.Join(path, file))
+		if strings.Contains(content, `kotlin("`) || strings.Contains(content, "org.jetbrains.kotlin") {
+			return true
+		}
+	}
+	info, err := os.Stat(filepath.Join(path, "src", "main", "kotlin"))
+	return err == nil && info.IsDir()
+}
+
 func detectKotlinBuildTool(path string) string {
 	if fileExists(path, "build.gradle.kts") || fileExists(path, "build.gradle") {
 		return "gradle"
@@ -239,6 +497,27 @@ func detectNodeVersion(path string) string {
 	return ""
 }
 
+// TypeScript detection
+func detectTypeScriptVersion(path string) string {
+	if content := readFile(filepath.Join(path, "package.json")); content != "" {
+		var pkg struct {
+			Dependencies    map[string]string `json:"dependencies"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		}
+		if err := json.Unmarshal([]byte(content), &pkg); err == nil {
+			version := pkg.DevDependencies["typescript"]
+			if version == "" {
+				version = pkg.Dependencies["typescript"]
+			}
+			version = strings.TrimLeft(version, "^~>=v")
+			if parts := strings.Split(version, "."); len(parts) >= 2 {
+				return parts[0] + "." + parts[1]
+			}
+		}
+	}
+	return ""
+}
+
 func detectNodeBuildTool(path string) string {
 	if fileExists(path, "pnpm-lock.yaml") {
 		return "pnpm"
@@ -249,9 +528,6 @@ func detectNodeBuildTool(path string) string {
 	if fileExists(path, "package-lock.json") {
 		return "npm"
 	}
-	if fileExists(path, "bun.lockb") {
-		return "bun"
-	}
 	return "npm"
 }
 
@@ -266,8 +542,29 @@ func detectGoVersion(path string) string {
 	return ""
 }
 
+func detectGoToolchain(path string) string {
+	if content := readFile(filepath.Join(path, "go.mod")); content != "" {
+		re := regexp.MustCompile(`(?m)^toolchain\s+go(\S+)`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
 // Rust detection
 func detectRustVersion(path string) string {
+	// rust-version in Cargo.toml is the minimum supported Rust version
+	if content := readFile(filepath.Join(path, "Cargo.toml")); content != "" {
+		re := regexp.MustCompile(`(?m)^rust-version\s*=\s*"([^"]+)"`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+	return detectRustToolchain(path)
+}
+
+func detectRustToolchain(path string) string {
 	if content := readFile(filepath.Join(path, "rust-toolchain")); content != "" {
 		return strings.TrimSpace(content)
 	}
@@ -318,22 +615,13 @@ func detectPHPVersion(path string) string {
 // .NET detection
 func detectDotNetVersion(path string) string {
 	// Check for global.json first
-	if content := readFile(filepath.Join(path, "global.json")); content != "" {
-		var globalJSON struct {
-			SDK struct {
-				Version string `json:"version"`
-			} `json:"sdk"`
-		}
-		if err := json.Unmarshal([]byte(content), &globalJSON); err == nil {
-			if globalJSON.SDK.Version != "" {
-				parts := strings.Split(globalJSON.SDK.Version, ".")
-				if len(parts) >= 2 {
-					if parts[1] == "0" {
-						return parts[0]
-					}
-					return parts[0] + "." + parts[1]
-				}
+	if sdkVersion := detectDotNetSDKVersion(path); sdkVersion != "" {
+		parts := strings.Split(sdkVersion, ".")
+		if len(parts) >= 2 {
+			if parts[1] == "0" {
+				return parts[0]
 			}
+			return parts[0] + "." + parts[1]
 		}
 	}
 
@@ -389,6 +677,36 @@ func detectDotNetVersion(path string) string {
 	return ""
 }
 
+// detectDotNetSDKVersion reads the SDK version pinned in global.json
+func detectDotNetSDKVersion(path string) string {
+	if content := readFile(filepath.Join(path, "global.json")); content != "" {
+		var globalJSON struct {
+			SDK struct {
+				Version string `json:"version"`
+			} `json:"sdk"`
+		}
+		if err := json.Unmarshal([]byte(content), &globalJSON); err == nil {
+			return globalJSON.SDK.Version
+		}
+	}
+	return ""
+}
+
+// detectCSharpVersion reads the C# language version from LangVersion in project files
+func detectCSharpVersion(path string) string {
+	patterns := []string{"*.csproj", "*/*.csproj", "Directory.Build.props"}
+	re := regexp.MustCompile(`<LangVersion>([^<]+)</LangVersion>`)
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(filepath.Join(path, pattern))
+		for _, file := range matches {
+			if match := re.FindStringSubmatch(readFile(file)); match != nil {
+				return strings.TrimSpace(match[1])
+			}
+		}
+	}
+	return ""
+}
+
 func parseNetVersion(framework string) (int, int) {
 	// Only match modern .NET: net5.0, net6.0, net8.0, net10.0, etc.
 	// Explicitly avoid netstandard, netcoreapp, net4xx (Framework)
@@ -463,6 +781,12 @@ func fileExists(basePath, filename string) bool {
 	return err == nil
 }
 
+// globFiles returns files in path matching a glob pattern
+func globFiles(path, pattern string) []string {
+	matches, _ := filepath.Glob(filepath.Join(path, pattern))
+	return matches
+}
+
 // POM represents a Maven POM file structure
 type POM struct {
 	XMLName    xml.Name `xml:"project"`
@@ -25,6 +25,11 @@ func TestDetectBuildTool(t *testing.T) {
 		{"Ruby bundle", "ruby", map[string]string{"Gemfile": ""}, "bundle"},
 		{"PHP composer", "php", map[string]string{"composer.json": ""}, "composer"},
 		{".NET dotnet", "dotnet", map[string]string{"project.csproj": ""}, "dotnet"},
+		{"Elixir mix", "elixir", map[string]string{"mix.exs": ""}, "mix"},
+		{"Erlang rebar3", "erlang", map[string]string{"rebar.config": ""}, "rebar3"},
+		{"Zig", "zig", map[string]string{"build.zig": ""}, "zig"},
+		{"Nim nimble", "nim", map[string]string{"app.nimble": ""}, "nimble"},
+		{"Crystal shards", "crystal", map[string]string{"shard.yml": ""}, "shards"},
 	}
 
 	for _, tt := range tests {
@@ -221,6 +226,24 @@ func TestDetectVersion(t *testing.T) {
 			},
 			expected: "20",
 		},
+		{
+			name:      "Kotlin plugin version",
+			language:  "kotlin",
+			buildTool: "gradle",
+			files: map[string]string{
+				"build.gradle.kts": "plugins {\n    kotlin(\"jvm\") version \"1.9.22\"\n}",
+			},
+			expected: "1.9.22",
+		},
+		{
+			name:      "TypeScript dependency",
+			language:  "typescript",
+			buildTool: "npm",
+			files: map[string]string{
+				"package.json": `{"devDependencies": {"typescript": "^5.3.3"}}`,
+			},
+			expected: "5.3",
+		},
 	}
 
 	for _, tt := range tests {
@@ -245,3 +268,141 @@ func TestDetectVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectRuntimeVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		language  string
+		buildTool string
+		files     map[string]string
+		expected  string
+	}{
+		{
+			name:      "Kotlin uses the JVM toolchain, not the plugin version",
+			language:  "kotlin",
+			buildTool: "gradle",
+			files: map[string]string{
+				"build.gradle.kts": "plugins {\n    kotlin(\"jvm\") version \"1.9.22\"\n}\nkotlin {\n    jvmToolchain(21)\n}",
+			},
+			expected: "21",
+		},
+		{
+			name:      "Kotlin jvmTarget",
+			language:  "kotlin",
+			buildTool: "gradle",
+			files: map[string]string{
+				"build.gradle.kts": "kotlinOptions {\n    jvmTarget = \"17\"\n}",
+			},
+			expected: "17",
+		},
+		{
+			name:      "Kotlin jvmTarget 1.8",
+			language:  "kotlin",
+			buildTool: "gradle",
+			files: map[string]string{
+				"build.gradle": "compileKotlin {\n    kotlinOptions.jvmTarget = \"1.8\"\n}",
+			},
+			expected: "8",
+		},
+		{
+			name:      "Kotlin JvmTarget.JVM_1_8",
+			language:  "kotlin",
+			buildTool: "gradle",
+			files: map[string]string{
+				"build.gradle.kts": "kotlin {\n    compilerOptions {\n        jvmTarget.set(JvmTarget.JVM_1_8)\n    }\n}",
+			},
+			expected: "8",
+		},
+		{
+			name:      "Scala with .java-version",
+			language:  "scala",
+			buildTool: "sbt",
+			files: map[string]string{
+				"build.sbt":     `scalaVersion := "3.3.1"`,
+				".java-version": "1.8",
+			},
+			expected: "8",
+		},
+		{
+			name:      "Java maven release",
+			language:  "java",
+			buildTool: "maven",
+			files: map[string]string{
+				"pom.xml": "<project><properties><maven.compiler.release>21</maven.compiler.release></properties></project>",
+			},
+			expected: "21",
+		},
+		{
+			name:      "TypeScript runs on Node.js",
+			language:  "typescript",
+			buildTool: "npm",
+			files: map[string]string{
+				"package.json": `{"engines": {"node": ">=20"}, "devDependencies": {"typescript": "^5.3.3"}}`,
+			},
+			expected: "20",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			result := DetectRuntimeVersion(tmpDir, tt.language, tt.buildTool)
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDetectToolchain(t *testing.T) {
+	tests := []struct {
+		name            string
+		language        string
+		buildTool       string
+		files           map[string]string
+		expectedName    string
+		expectedVersion string
+	}{
+		{"Go toolchain directive", "go", "go", map[string]string{"go.mod": "module test\n\ngo 1.22\n\ntoolchain go1.22.3"}, "go", "1.22.3"},
+		{"Go without toolchain", "go", "go", map[string]string{"go.mod": "module test\n\ngo 1.22"}, "", ""},
+		{"Rust toolchain file", "rust", "cargo", map[string]string{"rust-toolchain.toml": "[toolchain]\nchannel = \"1.76\""}, "rustc", "1.76"},
+		{".NET global.json", "dotnet", "dotnet", map[string]string{"global.json": `{"sdk": {"version": "8.0.100"}}`}, "dotnet-sdk", "8.0.100"},
+		{"JDK from sdkman", "java", "maven", map[string]string{".sdkmanrc": "java=21.0.2-tem"}, "jdk", "21.0.2-tem"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			name, version := DetectToolchain(tmpDir, tt.language, tt.buildTool)
+			if name != tt.expectedName || version != tt.expectedVersion {
+				t.Errorf("Expected %q %q, got %q %q", tt.expectedName, tt.expectedVersion, name, version)
+			}
+		})
+	}
+}
+
+// createTestFiles writes files into a new temporary directory and returns its path
+func createTestFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "techstack-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+
+	for filename, content := range files {
+		filePath := filepath.Join(tmpDir, filename)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatalf("Failed to create test directory: %v", err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	return tmpDir
+}
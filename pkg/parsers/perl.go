@@ -0,0 +1,60 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Perl detection
+func detectPerlVersion(path string) string {
+	// plenv pins the exact interpreter, e.g. "5.38.2"
+	if content := readFile(filepath.Join(path, ".perl-version")); content != "" {
+		return strings.TrimPrefix(strings.TrimSpace(content), "perl-")
+	}
+	if version := toolVersionsEntry(path, "perl"); version != "" {
+		return version
+	}
+
+	patterns := map[string]string{
+		"cpanfile":    `requires\s+['"]perl['"]\s*,\s*['"]?v?([\d._]+)`,
+		"Makefile.PL": `MIN_PERL_VERSION['"]?\s*=>\s*['"]?v?([\d._]+)`,
+		"Build.PL":    `requires\s*=>\s*\{[^}]*['"]?perl['"]?\s*=>\s*['"]?v?([\d._]+)`,
+	}
+	for _, file := range []string{"cpanfile", "Makefile.PL", "Build.PL"} {
+		if content := readFile(filepath.Join(path, file)); content != "" {
+			if match := regexp.MustCompile(patterns[file]).FindStringSubmatch(content); match != nil {
+				return normalizePerlVersion(match[1])
+			}
+		}
+	}
+	return ""
+}
+
+// normalizePerlVersion converts decimal Perl versions such as "5.036" to "5.36"
+func normalizePerlVersion(version string) string {
+	parts := strings.Split(strings.ReplaceAll(version, "_", ""), ".")
+	if len(parts) == 2 && len(parts[1]) >= 3 {
+		minor, err := strconv.Atoi(parts[1][:3])
+		if err == nil {
+			return parts[0] + "." + strconv.Itoa(minor)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func detectPerlBuildTool(path string) string {
+	switch {
+	case fileExists(path, "cpanfile.snapshot"):
+		return "carton"
+	case fileExists(path, "dist.ini"):
+		return "dzil"
+	case fileExists(path, "cpanfile"):
+		return "cpanm"
+	case fileExists(path, "Build.PL"):
+		return "module-build"
+	default:
+		return "make"
+	}
+}
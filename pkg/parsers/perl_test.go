@@ -0,0 +1,35 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectPerlVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expected      string
+		expectedBuild string
+	}{
+		{"cpanfile decimal version", map[string]string{"cpanfile": "requires 'perl', '5.036';\nrequires 'Mojolicious';\n"}, "5.36", "cpanm"},
+		{"cpanfile v-string", map[string]string{"cpanfile": "requires 'perl', 'v5.32.1';\n", "cpanfile.snapshot": ""}, "5.32.1", "carton"},
+		{"Makefile.PL", map[string]string{"Makefile.PL": "WriteMakefile(\n  NAME => 'App',\n  MIN_PERL_VERSION => '5.010',\n);\n"}, "5.10", "make"},
+		{".perl-version", map[string]string{"cpanfile": "requires 'perl', '5.020';\n", ".perl-version": "5.38.2\n"}, "5.38.2", "cpanm"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			buildTool := DetectBuildTool(tmpDir, "perl")
+			if buildTool != tt.expectedBuild {
+				t.Errorf("Expected build tool %q, got %q", tt.expectedBuild, buildTool)
+			}
+			if result := DetectVersion(tmpDir, "perl", buildTool); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// versionFileRuntimes maps single-version files to the runtime they pin
+var versionFileRuntimes = []struct{ file, runtime string }{
+	{".nvmrc", "node"},
+	{".node-version", "node"},
+	{".python-version", "python"},
+	{".ruby-version", "ruby"},
+	{".java-version", "jvm"},
+	{".go-version", "go"},
+	{".bun-version", "bun"},
+	{".dvmrc", "deno"},
+}
+
+// toolVersionsRuntimes maps asdf/mise tool names to runtimes
+var toolVersionsRuntimes = map[string]string{
+	"nodejs": "node",
+	"node":   "node",
+	"python": "python",
+	"ruby":   "ruby",
+	"java":   "jvm",
+	"golang": "go",
+	"go":     "go",
+	"rust":   "rust",
+	"php":    "php",
+	"erlang": "otp",
+	"bun":    "bun",
+	"deno":   "deno",
+	"dotnet": "dotnet",
+}
+
+// imageRuntimes maps image repositories to the runtime their tag versions
+var imageRuntimes = map[string]string{
+	"node":                             "node",
+	"python":                           "python",
+	"ruby":                             "ruby",
+	"golang":                           "go",
+	"rust":                             "rust",
+	"php":                              "php",
+	"eclipse-temurin":                  "jvm",
+	"openjdk":                          "jvm",
+	"amazoncorretto":                   "jvm",
+	"mcr.microsoft.com/dotnet/sdk":     "dotnet",
+	"mcr.microsoft.com/dotnet/aspnet":  "dotnet",
+	"mcr.microsoft.com/dotnet/runtime": "dotnet",
+	"erlang":                           "otp",
+	"oven/bun":                         "bun",
+	"denoland/deno":                    "deno",
+	"swift":                            "swift",
+	"dart":                             "dart",
+}
+
+// setupActionInputs maps GitHub Actions setup actions to the input taking the
+// runtime version and the runtime it installs
+var setupActionInputs = map[string]struct{ input, runtime string }{
+	"actions/setup-node":        {"node-version", "node"},
+	"actions/setup-python":      {"python-version", "python"},
+	"actions/setup-java":        {"java-version", "jvm"},
+	"actions/setup-go":          {"go-version", "go"},
+	"actions/setup-dotnet":      {"dotnet-version", "dotnet"},
+	"ruby/setup-ruby":           {"ruby-version", "ruby"},
+	"shivammathur/setup-php":    {"php-version", "php"},
+	"erlef/setup-beam":          {"otp-version", "otp"},
+	"dtolnay/rust-toolchain":    {"toolchain", "rust"},
+	"denoland/setup-deno":       {"deno-version", "deno"},
+	"oven-sh/setup-bun":         {"bun-version", "bun"},
+	"dart-lang/setup-dart":      {"sdk", "dart"},
+	"swift-actions/setup-swift": {"swift-version", "swift"},
+}
+
+// pinnedVersion matches the numeric part of a pinned version, e.g. 20 in v20,
+// 20.x or 20-alpine and 21.0.2 in temurin-21.0.2
+var pinnedVersion = regexp.MustCompile(`^(?:v|[a-z]+-)?(\d+(?:\.\d+)*)`)
+
+// DetectVersionPins lists the runtime versions a project pins outside its
+// manifest: version files such as .nvmrc, .tool-versions entries and Dockerfile
+// and Compose base images. CI configuration lives at the repository root and is
+// read by DetectCIVersionPins. Aliases such as lts/* or stable are skipped, as
+// they pin nothing.
+func DetectVersionPins(path string) []models.VersionPin {
+	var pins []models.VersionPin
+	add := func(runtime, version, file, source string) {
+		if pin, ok := versionPin(runtime, version, file, source); ok {
+			pins = append(pins, pin)
+		}
+	}
+
+	for _, vf := range versionFileRuntimes {
+		if content := readFile(filepath.Join(path, vf.file)); content != "" {
+			line, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+			add(vf.runtime, strings.TrimPrefix(line, "ruby-"), vf.file, vf.file)
+		}
+	}
+
+	for _, line := range strings.Split(readFile(filepath.Join(path, ".tool-versions")), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && toolVersionsRuntimes[fields[0]] != "" {
+			add(toolVersionsRuntimes[fields[0]], fields[1], ".tool-versions", fields[0])
+		}
+	}
+
+	for _, ref := range DetectImageReferences(path) {
+		if pin, ok := imagePin(ref.Image, ref.File); ok {
+			pins = append(pins, pin)
+		}
+	}
+	return pins
+}
+
+// CIVersionPin is a runtime version pinned by a CI job, with the directory the
+// job runs in
+type CIVersionPin struct {
+	models.VersionPin
+	// Dir is the job's directory relative to the repository root, or empty when
+	// the job does not name one
+	Dir string
+}
+
+// DetectCIVersionPins lists the runtime versions pinned by the CI configuration
+// at the repository root: GitLab CI images and the inputs and containers of
+// GitHub Actions setup steps. A job's directory is taken from its
+// working-directory or the version or dependency file of its setup step on
+// GitHub, and from a cd in its script or the paths of its rules:changes on
+// GitLab, so monorepo pipelines can be matched to their projects. Versions taken
+// from expressions or variables are skipped.
+func DetectCIVersionPins(root string) []CIVersionPin {
+	var pins []CIVersionPin
+	add := func(pin models.VersionPin, ok bool, dir string) {
+		if ok {
+			pins = append(pins, CIVersionPin{VersionPin: pin, Dir: dir})
+		}
+	}
+
+	for _, job := range gitlabJobs(filepath.Join(root, ".gitlab-ci.yml")) {
+		pin, ok := imagePin(job.image, ".gitlab-ci.yml")
+		add(pin, ok, job.dir)
+	}
+
+	for _, file := range slices.Concat(globFiles(root, ".github/workflows/*.yml"), globFiles(root, ".github/workflows/*.yaml")) {
+		var workflow struct {
+			Jobs map[string]struct {
+				Container yaml.Node `yaml:"container"`
+				Defaults  struct {
+					Run struct {
+						WorkingDirectory string `yaml:"working-directory"`
+					} `yaml:"run"`
+				} `yaml:"defaults"`
+				Steps []struct {
+					Uses             string            `yaml:"uses"`
+					With             map[string]string `yaml:"with"`
+					WorkingDirectory string            `yaml:"working-directory"`
+				} `yaml:"steps"`
+			} `yaml:"jobs"`
+		}
+		if err := yaml.Unmarshal([]byte(readFile(file)), &workflow); err != nil {
+			continue
+		}
+		rel := relPath(root, file)
+		jobs := make([]string, 0, len(workflow.Jobs))
+		for name := range workflow.Jobs {
+			jobs = append(jobs, name)
+		}
+		slices.Sort(jobs)
+		for _, name := range jobs {
+			job := workflow.Jobs[name]
+			dir := job.Defaults.Run.WorkingDirectory
+			for _, step := range job.Steps {
+				if dir == "" {
+					dir = step.WorkingDirectory
+				}
+			}
+			dir = jobDir(dir)
+
+			if image := yamlImage(&job.Container); !strings.Contains(image, "${{") {
+				pin, ok := imagePin(image, rel)
+				add(pin, ok, dir)
+			}
+			for _, step := range job.Steps {
+				action, _, _ := strings.Cut(step.Uses, "@")
+				setup, ok := setupActionInputs[action]
+				if version := step.With[setup.input]; ok && !strings.Contains(version, "${{") {
+					stepDir := dir
+					if file := step.With["cache-dependency-path"]; file != "" && !strings.ContainsAny(file, "*\n") {
+						stepDir = jobDir(path.Dir(file))
+					}
+					pin, ok := versionPin(setup.runtime, version, rel, action+" "+setup.input)
+					add(pin, ok, stepDir)
+				}
+			}
+		}
+	}
+	return pins
+}
+
+// versionPin builds the pin of a runtime from the numeric part of a version
+func versionPin(runtime, version, file, source string) (models.VersionPin, bool) {
+	match := pinnedVersion.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		return models.VersionPin{}, false
+	}
+	return models.VersionPin{Runtime: runtime, Version: match[1], File: file, Source: source}, true
+}
+
+// imagePin builds the pin of the runtime an image provides from its tag
+func imagePin(image, file string) (models.VersionPin, bool) {
+	repository, tag := splitImage(image)
+	if runtime, ok := imageRuntimes[repository]; ok && tag != "" {
+		return versionPin(runtime, tag, file, image)
+	}
+	return models.VersionPin{}, false
+}
+
+// jobDir cleans the directory a CI job runs in to a path relative to the
+// repository root. Directories built from expressions or variables other than
+// the project directory are dropped.
+func jobDir(dir string) string {
+	for _, prefix := range []string{"$CI_PROJECT_DIR", "${CI_PROJECT_DIR}", "${{ github.workspace }}", "$GITHUB_WORKSPACE"} {
+		dir = strings.TrimPrefix(dir, prefix)
+	}
+	dir = strings.TrimPrefix(strings.TrimSpace(dir), "/")
+	if dir == "" || strings.Contains(dir, "$") {
+		return ""
+	}
+	return path.Clean(dir)
+}
+
+// gitlabJob is a GitLab CI image with the directory of the job using it
+type gitlabJob struct {
+	image string
+	dir   string
+}
+
+// gitlabJobs lists the images of a GitLab CI pipeline: the global and default
+// images and those of its jobs, with the directory a job changes into or the
+// one its rules:changes paths start with. Images built from variables are
+// skipped.
+func gitlabJobs(file string) []gitlabJob {
+	var pipeline map[string]yaml.Node
+	if err := yaml.Unmarshal([]byte(readFile(file)), &pipeline); err != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(pipeline))
+	for key := range pipeline {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var jobs []gitlabJob
+	for _, key := range keys {
+		node := pipeline[key]
+		var job gitlabJob
+		if key == "image" {
+			job.image = yamlImage(&node)
+		} else {
+			var spec struct {
+				Image        yaml.Node `yaml:"image"`
+				BeforeScript yaml.Node `yaml:"before_script"`
+				Script       yaml.Node `yaml:"script"`
+				Rules        []struct {
+					Changes []string `yaml:"changes"`
+				} `yaml:"rules"`
+			}
+			if node.Kind != yaml.MappingNode || node.Decode(&spec) != nil {
+				continue
+			}
+			job.image = yamlImage(&spec.Image)
+			for _, line := range slices.Concat(yamlLines(&spec.BeforeScript), yamlLines(&spec.Script)) {
+				if fields := strings.Fields(line); job.dir == "" && len(fields) >= 2 && fields[0] == "cd" {
+					job.dir = jobDir(strings.TrimRight(fields[1], ";&"))
+				}
+			}
+			for _, rule := range spec.Rules {
+				for _, change := range rule.Changes {
+					static, _, _ := strings.Cut(change, "*")
+					if job.dir == "" && strings.HasSuffix(static, "/") {
+						job.dir = jobDir(static)
+					} else if job.dir == "" && strings.Contains(static, "/") {
+						job.dir = jobDir(path.Dir(static))
+					}
+				}
+			}
+		}
+		if job.image != "" && !strings.Contains(job.image, "$") {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// yamlLines reads a script given either as a single string or as a list of lines
+func yamlLines(node *yaml.Node) []string {
+	var lines []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		lines = strings.Split(node.Value, "\n")
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			lines = append(lines, strings.Split(item.Value, "\n")...)
+		}
+	}
+	return lines
+}
+
+// yamlImage reads an image given either as a string or as a mapping with the
+// image in its name (GitLab) or image (GitHub Actions) key
+func yamlImage(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value
+	case yaml.MappingNode:
+		var image struct {
+			Name  string `yaml:"name"`
+			Image string `yaml:"image"`
+		}
+		if node.Decode(&image) == nil {
+			return image.Name + image.Image
+		}
+	}
+	return ""
+}
+
+// pyprojectPythonRange matches the Python range of pyproject.toml, either PEP 621
+// requires-python or the python dependency of Poetry
+var pyprojectPythonRange = regexp.MustCompile(`(?m)^\s*(?:requires-python|python)\s*=\s*["']([^"']+)["']`)
+
+// gemfileRubyRange matches the ruby directive of a Gemfile, e.g. ruby "~> 3.2"
+var gemfileRubyRange = regexp.MustCompile(`(?m)^\s*ruby\s+['"]([^'"]+)['"]`)
+
+// DetectRuntimeRange reads the runtime version range a project's manifest
+// declares as written, e.g. ">=18" from the engines field of package.json or
+// "^3.11" from pyproject.toml. It returns an empty string when the manifest
+// declares none.
+func DetectRuntimeRange(path, language string) string {
+	switch language {
+	case "node", "javascript", "typescript":
+		var pkg struct {
+			Engines struct {
+				Node string `json:"node"`
+			} `json:"engines"`
+		}
+		if json.Unmarshal([]byte(readFile(filepath.Join(path, "package.json"))), &pkg) == nil {
+			return strings.TrimSpace(pkg.Engines.Node)
+		}
+	case "python":
+		if match := pyprojectPythonRange.FindStringSubmatch(readFile(filepath.Join(path, "pyproject.toml"))); match != nil {
+			return strings.TrimSpace(match[1])
+		}
+	case "php":
+		var composer struct {
+			Require struct {
+				PHP string `json:"php"`
+			} `json:"require"`
+		}
+		if json.Unmarshal([]byte(readFile(filepath.Join(path, "composer.json"))), &composer) == nil {
+			return strings.TrimSpace(composer.Require.PHP)
+		}
+	case "ruby":
+		if match := gemfileRubyRange.FindStringSubmatch(readFile(filepath.Join(path, "Gemfile"))); match != nil {
+			return strings.TrimSpace(match[1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,190 @@
+package parsers
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+func TestDetectVersionPins(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected []models.VersionPin
+	}{
+		{
+			name: "Version files",
+			files: map[string]string{
+				".nvmrc":         "v20.11.1\n",
+				".ruby-version":  "ruby-3.3.0\n",
+				".tool-versions": "nodejs 20.11.1\njava temurin-21.0.2+13.0.LTS\nterraform 1.7.0\n",
+			},
+			expected: []models.VersionPin{
+				{Runtime: "node", Version: "20.11.1", File: ".nvmrc", Source: ".nvmrc"},
+				{Runtime: "ruby", Version: "3.3.0", File: ".ruby-version", Source: ".ruby-version"},
+				{Runtime: "node", Version: "20.11.1", File: ".tool-versions", Source: "nodejs"},
+				{Runtime: "jvm", Version: "21.0.2", File: ".tool-versions", Source: "java"},
+			},
+		},
+		{
+			name:  "Dockerfile images",
+			files: map[string]string{"Dockerfile": "FROM node:18-alpine AS build\nFROM nginx:1.25\n"},
+			expected: []models.VersionPin{
+				{Runtime: "node", Version: "18", File: "Dockerfile", Source: "node:18-alpine"},
+			},
+		},
+		{
+			name:     "Aliases pin nothing",
+			files:    map[string]string{".nvmrc": "lts/iron\n", "Dockerfile": "FROM node:lts-alpine\n"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			result := DetectVersionPins(tmpDir)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDetectCIVersionPins(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected []CIVersionPin
+	}{
+		{
+			name: "GitLab CI images",
+			files: map[string]string{
+				".gitlab-ci.yml": `image: node:20
+variables:
+  A: b
+test:
+  image:
+    name: python:3.12-slim
+  script: [pytest]
+lint:
+  image: $LINT_IMAGE
+web:
+  image: node:18
+  before_script:
+    - cd $CI_PROJECT_DIR/apps/web
+  script: npm test
+api:
+  image: golang:1.22
+  rules:
+    - changes:
+        - services/api/**/*
+`,
+			},
+			expected: []CIVersionPin{
+				{VersionPin: models.VersionPin{Runtime: "go", Version: "1.22", File: ".gitlab-ci.yml", Source: "golang:1.22"}, Dir: "services/api"},
+				{VersionPin: models.VersionPin{Runtime: "node", Version: "20", File: ".gitlab-ci.yml", Source: "node:20"}},
+				{VersionPin: models.VersionPin{Runtime: "python", Version: "3.12", File: ".gitlab-ci.yml", Source: "python:3.12-slim"}},
+				{VersionPin: models.VersionPin{Runtime: "node", Version: "18", File: ".gitlab-ci.yml", Source: "node:18"}, Dir: "apps/web"},
+			},
+		},
+		{
+			name: "GitHub Actions setup steps and containers",
+			files: map[string]string{
+				".github/workflows/ci.yml": `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    container: golang:1.22
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 20.x
+      - uses: actions/setup-python@v5
+        with:
+          python-version: 3.10
+  matrix:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/setup-node@v4
+        with:
+          node-version: ${{ matrix.node }}
+      - uses: actions/setup-java@v4
+        with:
+          distribution: temurin
+          java-version: 21
+  web:
+    runs-on: ubuntu-latest
+    defaults:
+      run:
+        working-directory: ./apps/web
+    steps:
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 22
+  docs:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 18
+          cache-dependency-path: docs/package-lock.json
+      - run: npm ci
+        working-directory: docs
+`,
+			},
+			expected: []CIVersionPin{
+				{VersionPin: models.VersionPin{Runtime: "go", Version: "1.22", File: ".github/workflows/ci.yml", Source: "golang:1.22"}},
+				{VersionPin: models.VersionPin{Runtime: "node", Version: "20", File: ".github/workflows/ci.yml", Source: "actions/setup-node node-version"}},
+				{VersionPin: models.VersionPin{Runtime: "python", Version: "3.10", File: ".github/workflows/ci.yml", Source: "actions/setup-python python-version"}},
+				{VersionPin: models.VersionPin{Runtime: "node", Version: "18", File: ".github/workflows/ci.yml", Source: "actions/setup-node node-version"}, Dir: "docs"},
+				{VersionPin: models.VersionPin{Runtime: "jvm", Version: "21", File: ".github/workflows/ci.yml", Source: "actions/setup-java java-version"}},
+				{VersionPin: models.VersionPin{Runtime: "node", Version: "22", File: ".github/workflows/ci.yml", Source: "actions/setup-node node-version"}, Dir: "apps/web"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			result := DetectCIVersionPins(tmpDir)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDetectRuntimeRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		files    map[string]string
+		expected string
+	}{
+		{"engines.node", "typescript", map[string]string{"package.json": `{"engines": {"node": ">=18 <23"}}`}, ">=18 <23"},
+		{"requires-python", "python", map[string]string{"pyproject.toml": "[project]\nrequires-python = \">=3.11\"\n"}, ">=3.11"},
+		{"Poetry python", "python", map[string]string{"pyproject.toml": "[tool.poetry.dependencies]\npython = \"^3.11\"\n"}, "^3.11"},
+		{"Composer php", "php", map[string]string{"composer.json": `{"require": {"php": "^8.1"}}`}, "^8.1"},
+		{"Gemfile ruby", "ruby", map[string]string{"Gemfile": "source \"https://rubygems.org\"\nruby \"~> 3.2\"\n"}, "~> 3.2"},
+		{"No range", "node", map[string]string{"package.json": `{"name": "app"}`}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectRuntimeRange(tmpDir, tt.language); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
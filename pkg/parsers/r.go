@@ -0,0 +1,59 @@
+package parsers
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// R detection
+func detectRVersion(path string) string {
+	// renv.lock records the exact R version the library was snapshotted with
+	if content := readFile(filepath.Join(path, "renv.lock")); content != "" {
+		var lock struct {
+			R struct {
+				Version string `json:"Version"`
+			} `json:"R"`
+		}
+		if err := json.Unmarshal([]byte(content), &lock); err == nil && lock.R.Version != "" {
+			return lock.R.Version
+		}
+	}
+
+	// DESCRIPTION: Depends: R (>= 4.2), methods
+	if depends := descriptionField(readFile(filepath.Join(path, "DESCRIPTION")), "Depends"); depends != "" {
+		re := regexp.MustCompile(`\bR\s*\(\s*>=?\s*([\d.]+)\s*\)`)
+		if match := re.FindStringSubmatch(depends); match != nil {
+			return match[1]
+		}
+	}
+
+	return ""
+}
+
+// descriptionField reads a field of a DESCRIPTION file, joining the continuation
+// lines that follow it, which start with whitespace
+func descriptionField(content, name string) string {
+	var value []string
+	inField := false
+	for _, line := range strings.Split(content, "\n") {
+		switch {
+		case strings.HasPrefix(line, name+":"):
+			inField = true
+			value = append(value, strings.TrimPrefix(line, name+":"))
+		case inField && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")):
+			value = append(value, line)
+		default:
+			inField = false
+		}
+	}
+	return strings.TrimSpace(strings.Join(value, " "))
+}
+
+func detectRBuildTool(path string) string {
+	if fileExists(path, "renv.lock") || fileExists(path, "renv") {
+		return "renv"
+	}
+	return "pak"
+}
@@ -0,0 +1,64 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectRVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expected      string
+		expectedBuild string
+	}{
+		{
+			name:          "renv.lock",
+			files:         map[string]string{"renv.lock": `{"R": {"Version": "4.3.2", "Repositories": []}, "Packages": {}}`},
+			expected:      "4.3.2",
+			expectedBuild: "renv",
+		},
+		{
+			name:          "DESCRIPTION Depends",
+			files:         map[string]string{"DESCRIPTION": "Package: analysis\nDepends: R (>= 4.2), methods\nImports: dplyr\n"},
+			expected:      "4.2",
+			expectedBuild: "pak",
+		},
+		{
+			name:          "DESCRIPTION Depends on continuation lines",
+			files:         map[string]string{"DESCRIPTION": "Package: analysis\nDepends:\n    methods,\n\tR (>= 4.1.0)\nImports: dplyr, R6 (>= 2.5)\n"},
+			expected:      "4.1.0",
+			expectedBuild: "pak",
+		},
+		{
+			name:          "R outside Depends",
+			files:         map[string]string{"DESCRIPTION": "Package: analysis\nDepends: methods\nSuggests:\n    R (>= 3.0)\n"},
+			expected:      "",
+			expectedBuild: "pak",
+		},
+		{
+			name: "renv.lock wins over DESCRIPTION",
+			files: map[string]string{
+				"DESCRIPTION": "Package: analysis\nDepends: R (>= 4.0)\n",
+				"renv.lock":   `{"R": {"Version": "4.4.1"}}`,
+			},
+			expected:      "4.4.1",
+			expectedBuild: "renv",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			buildTool := DetectBuildTool(tmpDir, "r")
+			if buildTool != tt.expectedBuild {
+				t.Errorf("Expected build tool %q, got %q", tt.expectedBuild, buildTool)
+			}
+			if result := DetectVersion(tmpDir, "r", buildTool); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// serviceOrder lists the backing services that can be detected, in output order
+var serviceOrder = []string{"postgres", "mysql", "mariadb", "redis", "kafka", "rabbitmq", "mongodb", "elasticsearch"}
+
+// serviceImages maps container image repositories to the service they run
+var serviceImages = map[string]string{
+	"postgres":              "postgres",
+	"postgis/postgis":       "postgres",
+	"bitnami/postgresql":    "postgres",
+	"mysql":                 "mysql",
+	"bitnami/mysql":         "mysql",
+	"mariadb":               "mariadb",
+	"bitnami/mariadb":       "mariadb",
+	"redis":                 "redis",
+	"bitnami/redis":         "redis",
+	"redis/redis-stack":     "redis",
+	"confluentinc/cp-kafka": "kafka",
+	"bitnami/kafka":         "kafka",
+	"apache/kafka":          "kafka",
+	"rabbitmq":              "rabbitmq",
+	"bitnami/rabbitmq":      "rabbitmq",
+	"mongo":                 "mongodb",
+	"bitnami/mongodb":       "mongodb",
+	"elasticsearch":         "elasticsearch",
+	"bitnami/elasticsearch": "elasticsearch",
+	"docker.elastic.co/elasticsearch/elasticsearch": "elasticsearch",
+}
+
+// serviceDependencies maps client libraries to the service they talk to, per package ecosystem
+var serviceDependencies = map[string]map[string]string{
+	"python": {
+		"psycopg":                "postgres",
+		"psycopg2":               "postgres",
+		"psycopg2-binary":        "postgres",
+		"asyncpg":                "postgres",
+		"mysqlclient":            "mysql",
+		"pymysql":                "mysql",
+		"mysql-connector-python": "mysql",
+		"aiomysql":               "mysql",
+		"redis":                  "redis",
+		"aioredis":               "redis",
+		"kafka-python":           "kafka",
+		"confluent-kafka":        "kafka",
+		"aiokafka":               "kafka",
+		"pika":                   "rabbitmq",
+		"aio-pika":               "rabbitmq",
+		"pymongo":                "mongodb",
+		"motor":                  "mongodb",
+		"elasticsearch":          "elasticsearch",
+	},
+	"node": {
+		"pg":                      "postgres",
+		"postgres":                "postgres",
+		"pg-promise":              "postgres",
+		"mysql":                   "mysql",
+		"mysql2":                  "mysql",
+		"redis":                   "redis",
+		"ioredis":                 "redis",
+		"kafkajs":                 "kafka",
+		"amqplib":                 "rabbitmq",
+		"amqp-connection-manager": "rabbitmq",
+		"mongodb":                 "mongodb",
+		"mongoose":                "mongodb",
+		"@elastic/elasticsearch":  "elasticsearch",
+	},
+	"ruby": {
+		"pg":            "postgres",
+		"mysql2":        "mysql",
+		"redis":         "redis",
+		"sidekiq":       "redis",
+		"ruby-kafka":    "kafka",
+		"rdkafka":       "kafka",
+		"karafka":       "kafka",
+		"bunny":         "rabbitmq",
+		"sneakers":      "rabbitmq",
+		"mongo":         "mongodb",
+		"mongoid":       "mongodb",
+		"elasticsearch": "elasticsearch",
+		"searchkick":    "elasticsearch",
+	},
+	"php": {
+		"ext-pgsql":                   "postgres",
+		"ext-pdo_pgsql":               "postgres",
+		"ext-pdo_mysql":               "mysql",
+		"ext-mysqli":                  "mysql",
+		"ext-redis":                   "redis",
+		"predis/predis":               "redis",
+		"ext-rdkafka":                 "kafka",
+		"php-amqplib/php-amqplib":     "rabbitmq",
+		"mongodb/mongodb":             "mongodb",
+		"elasticsearch/elasticsearch": "elasticsearch",
+	},
+	"go": {
+		"github.com/lib/pq":                          "postgres",
+		"github.com/jackc/pgx":                       "postgres",
+		"github.com/go-sql-driver/mysql":             "mysql",
+		"github.com/redis/go-redis":                  "redis",
+		"github.com/go-redis/redis":                  "redis",
+		"github.com/gomodule/redigo":                 "redis",
+		"github.com/segmentio/kafka-go":              "kafka",
+		"github.com/IBM/sarama":                      "kafka",
+		"github.com/Shopify/sarama":                  "kafka",
+		"github.com/confluentinc/confluent-kafka-go": "kafka",
+		"github.com/rabbitmq/amqp091-go":             "rabbitmq",
+		"github.com/streadway/amqp":                  "rabbitmq",
+		"go.mongodb.org/mongo-driver":                "mongodb",
+		"github.com/elastic/go-elasticsearch":        "elasticsearch",
+	},
+	"elixir": {
+		"postgrex":          "postgres",
+		"myxql":             "mysql",
+		"redix":             "redis",
+		"brod":              "kafka",
+		"broadway_kafka":    "kafka",
+		"amqp":              "rabbitmq",
+		"broadway_rabbitmq": "rabbitmq",
+		"mongodb_driver":    "mongodb",
+		"elasticsearch":     "elasticsearch",
+	},
+	"rust": {
+		"tokio-postgres": "postgres",
+		"postgres":       "postgres",
+		"mysql":          "mysql",
+		"mysql_async":    "mysql",
+		"redis":          "redis",
+		"rdkafka":        "kafka",
+		"lapin":          "rabbitmq",
+		"mongodb":        "mongodb",
+		"elasticsearch":  "elasticsearch",
+	},
+	// JVM and .NET dependencies are matched as substrings of the build files
+	"jvm": {
+		"org.postgresql":                         "postgres",
+		"mysql-connector":                        "mysql",
+		"mariadb-java-client":                    "mariadb",
+		"spring-boot-starter-data-redis":         "redis",
+		"redis.clients":                          "redis",
+		"io.lettuce":                             "redis",
+		"spring-kafka":                           "kafka",
+		"kafka-clients":                          "kafka",
+		"spring-boot-starter-amqp":               "rabbitmq",
+		"com.rabbitmq":                           "rabbitmq",
+		"spring-boot-starter-data-mongodb":       "mongodb",
+		"mongodb-driver":                         "mongodb",
+		"spring-boot-starter-data-elasticsearch": "elasticsearch",
+		"elasticsearch-java":                     "elasticsearch",
+	},
+	"dotnet": {
+		`Include="Npgsql`:                            "postgres",
+		`Include="MySqlConnector"`:                   "mysql",
+		`Include="MySql.Data"`:                       "mysql",
+		`Include="Pomelo.EntityFrameworkCore.MySql"`: "mysql",
+		`Include="StackExchange.Redis"`:              "redis",
+		`Include="Confluent.Kafka"`:                  "kafka",
+		`Include="RabbitMQ.Client"`:                  "rabbitmq",
+		`Include="MassTransit.RabbitMQ"`:             "rabbitmq",
+		`Include="MongoDB.Driver"`:                   "mongodb",
+		`Include="Elastic.Clients.Elasticsearch"`:    "elasticsearch",
+		`Include="NEST"`:                             "elasticsearch",
+	},
+}
+
+// DetectServices detects backing services such as databases and message brokers.
+// Services declared in Compose files carry the pinned image and version; services
+// only implied by a client library have no version.
+func DetectServices(path, language string) []models.Service {
+	found := detectComposeServices(path)
+
+	for _, service := range detectDependencyServices(path, language) {
+		if _, ok := found[service]; !ok {
+			found[service] = models.Service{Name: service}
+		}
+	}
+
+	var services []models.Service
+	for _, name := range serviceOrder {
+		if service, ok := found[name]; ok {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// detectComposeServices reads services from docker-compose and compose files
+func detectComposeServices(path string) map[string]models.Service {
+	found := make(map[string]models.Service)
+
+	for _, file := range composeFiles(path) {
+		var compose struct {
+			Services map[string]struct {
+				Image string `yaml:"image"`
+			} `yaml:"services"`
+		}
+		if err := yaml.Unmarshal([]byte(readFile(file)), &compose); err != nil {
+			continue
+		}
+
+		names := make([]string, 0, len(compose.Services))
+		for name := range compose.Services {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			image := interpolateDefaults(compose.Services[name].Image)
+			repository, tag := splitImage(image)
+			service, ok := serviceImages[repository]
+			if !ok {
+				continue
+			}
+			// The first pinned declaration wins, e.g. docker-compose.yml over an override file
+			if existing, ok := found[service]; ok && existing.Version != "" {
+				continue
+			}
+			found[service] = models.Service{
+				Name:    service,
+				Version: regexp.MustCompile(`^\d+(?:\.\d+)*`).FindString(tag),
+				Image:   image,
+			}
+		}
+	}
+	return found
+}
+
+// composeFiles lists the docker-compose and compose files of a project
+func composeFiles(path string) []string {
+	var files []string
+	for _, pattern := range []string{"compose.yaml", "compose.yml", "docker-compose.yml", "docker-compose.yaml", "docker-compose.*.yml", "docker-compose.*.yaml"} {
+		files = append(files, globFiles(path, pattern)...)
+	}
+	return files
+}
+
+// composeInterpolation matches ${VAR:-default} and ${VAR-default}
+var composeInterpolation = regexp.MustCompile(`\$\{[^:}-]+:?-([^}]*)\}`)
+
+// interpolateDefaults resolves Compose variables to their default values
+func interpolateDefaults(value string) string {
+	return composeInterpolation.ReplaceAllString(value, "$1")
+}
+
+// splitImage splits an image reference into repository and tag, dropping the
+// registry for Docker Hub images and any digest
+func splitImage(image string) (repository, tag string) {
+	image, _, _ = strings.Cut(image, "@")
+	repository = image
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		repository, tag = image[:idx], image[idx+1:]
+	}
+	repository = strings.TrimPrefix(repository, "docker.io/")
+	repository = strings.TrimPrefix(repository, "library/")
+	return repository, tag
+}
+
+// detectDependencyServices finds services implied by the client libraries a project depends on
+func detectDependencyServices(path, language string) []string {
+	switch language {
+	case "java", "kotlin", "scala", "clojure":
+		return matchServiceMarkers("jvm", path, "pom.xml", "build.gradle.kts", "build.gradle", "build.sbt",
+			"project.clj", "deps.edn", filepath.Join("gradle", "libs.versions.toml"))
+	case "dotnet", "csharp":
+		return matchServiceMarkers("dotnet", path, "*.csproj", "*/*.csproj", "*/*/*.csproj", "Directory.Packages.props")
+	}
+
+	ecosystem, deps := ecosystemDependencies(path, language)
+
+	var services []string
+	for pkg, service := range serviceDependencies[ecosystem] {
+		if _, ok := deps[pkg]; ok {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// ecosystemDependencies reads the direct dependencies of a project from the manifest
+// of its package ecosystem. Languages without a dependency reader return no ecosystem.
+func ecosystemDependencies(path, language string) (string, map[string]string) {
+	switch language {
+	case "python":
+		return "python", pythonDependencies(path)
+	case "node", "javascript", "typescript", "bun":
+		return "node", nodeDependencies(path)
+	case "ruby":
+		return "ruby", rubyDependencies(path)
+	case "php":
+		return "php", phpDependencies(path)
+	case "go":
+		return "go", goDependencies(path)
+	case "elixir":
+		return "elixir", elixirDependencies(path)
+	case "rust":
+		return "rust", rustDependencies(path)
+	default:
+		return "", nil
+	}
+}
+
+// matchServiceMarkers looks for client library names in build files matching the given patterns
+func matchServiceMarkers(ecosystem, path string, patterns ...string) []string {
+	var content string
+	for _, pattern := range patterns {
+		for _, file := range globFiles(path, pattern) {
+			content += readFile(file) + "\n"
+		}
+	}
+
+	var services []string
+	for marker, service := range serviceDependencies[ecosystem] {
+		if strings.Contains(content, marker) {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// rustDependencies lists the crates a Cargo manifest depends on
+func rustDependencies(path string) map[string]string {
+	deps := make(map[string]string)
+	content := readFile(filepath.Join(path, "Cargo.toml"))
+	for _, table := range []string{"dependencies", "workspace.dependencies"} {
+		for _, name := range tomlTableKeys(content, table) {
+			deps[name] = ""
+		}
+	}
+	return deps
+}
@@ -0,0 +1,122 @@
+package parsers
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stack-radar/stackradar/pkg/models"
+)
+
+func TestDetectServices(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		files    map[string]string
+		expected []models.Service
+	}{
+		{
+			name:     "Compose services with pinned tags",
+			language: "python",
+			files: map[string]string{
+				"docker-compose.yml": "services:\n  app:\n    build: .\n  db:\n    image: postgres:15-alpine\n  cache:\n    image: redis:7.2\n  mail:\n    image: mailhog/mailhog\n",
+			},
+			expected: []models.Service{
+				{Name: "postgres", Version: "15", Image: "postgres:15-alpine"},
+				{Name: "redis", Version: "7.2", Image: "redis:7.2"},
+			},
+		},
+		{
+			name:     "compose.yaml with registry and interpolation",
+			language: "go",
+			files: map[string]string{
+				"compose.yaml": "services:\n  search:\n    image: docker.elastic.co/elasticsearch/elasticsearch:${ES_VERSION:-8.13.0}\n  broker:\n    image: docker.io/library/rabbitmq:3.13-management\n",
+			},
+			expected: []models.Service{
+				{Name: "rabbitmq", Version: "3.13", Image: "docker.io/library/rabbitmq:3.13-management"},
+				{Name: "elasticsearch", Version: "8.13.0", Image: "docker.elastic.co/elasticsearch/elasticsearch:8.13.0"},
+			},
+		},
+		{
+			name:     "Unpinned compose image",
+			language: "node",
+			files:    map[string]string{"docker-compose.dev.yaml": "services:\n  mongo:\n    image: mongo\n"},
+			expected: []models.Service{{Name: "mongodb", Image: "mongo"}},
+		},
+		{
+			name:     "Python client libraries",
+			language: "python",
+			files:    map[string]string{"requirements.txt": "psycopg[binary]>=3.1\ncelery\nredis==5.0.1\n"},
+			expected: []models.Service{{Name: "postgres"}, {Name: "redis"}},
+		},
+		{
+			name:     "Compose version wins over dependency",
+			language: "node",
+			files: map[string]string{
+				"package.json":       `{"dependencies": {"pg": "^8.11.0", "kafkajs": "^2.2.4"}}`,
+				"docker-compose.yml": "services:\n  postgres:\n    image: postgres:16\n",
+			},
+			expected: []models.Service{
+				{Name: "postgres", Version: "16", Image: "postgres:16"},
+				{Name: "kafka"},
+			},
+		},
+		{
+			name:     "Spring Boot starters",
+			language: "java",
+			files: map[string]string{
+				"pom.xml": "<project><dependencies>" +
+					"<dependency><groupId>org.springframework.boot</groupId><artifactId>spring-boot-starter-data-redis</artifactId></dependency>" +
+					"<dependency><groupId>org.postgresql</groupId><artifactId>postgresql</artifactId></dependency>" +
+					"</dependencies></project>",
+			},
+			expected: []models.Service{{Name: "postgres"}, {Name: "redis"}},
+		},
+		{
+			name:     "Go modules with major version suffix",
+			language: "go",
+			files: map[string]string{
+				"go.mod": "module x\n\nrequire (\n\tgithub.com/jackc/pgx/v5 v5.5.5\n\tgo.mongodb.org/mongo-driver v1.15.0\n)\n",
+			},
+			expected: []models.Service{{Name: "postgres"}, {Name: "mongodb"}},
+		},
+		{
+			name:     "Rails gems",
+			language: "ruby",
+			files:    map[string]string{"Gemfile": "gem 'rails'\ngem 'mysql2'\ngem 'sidekiq'\n"},
+			expected: []models.Service{{Name: "mysql"}, {Name: "redis"}},
+		},
+		{
+			name:     ".NET packages",
+			language: "csharp",
+			files: map[string]string{
+				"Api/Api.csproj": `<PackageReference Include="Npgsql.EntityFrameworkCore.PostgreSQL" Version="8.0.2" /><PackageReference Include="RabbitMQ.Client" Version="6.8.1" />`,
+			},
+			expected: []models.Service{{Name: "postgres"}, {Name: "rabbitmq"}},
+		},
+		{
+			name:     "Rust crates",
+			language: "rust",
+			files:    map[string]string{"Cargo.toml": "[package]\nname = \"svc\"\n\n[dependencies]\ntokio-postgres = \"0.7\"\nlapin = { version = \"2\" }\n"},
+			expected: []models.Service{{Name: "postgres"}, {Name: "rabbitmq"}},
+		},
+		{
+			name:     "No services",
+			language: "python",
+			files:    map[string]string{"requirements.txt": "requests\n"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			result := DetectServices(tmpDir, tt.language)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
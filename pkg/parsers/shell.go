@@ -0,0 +1,70 @@
+package parsers
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var shebangPattern = regexp.MustCompile(`^#!\s*(?:/usr)?(?:/local)?/bin/(?:env\s+(?:-S\s+)?)?(bash|zsh|sh|dash|ash|ksh)\b`)
+
+// detectShellInterpreter picks the interpreter a shell repository needs from script shebangs.
+// Bash runs POSIX sh scripts too, so sh is only chosen when no script requires more.
+func detectShellInterpreter(path, fallback string) string {
+	counts := make(map[string]int)
+	for _, dir := range []string{".", "bin", "scripts"} {
+		entries, err := os.ReadDir(filepath.Join(path, dir))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			ext := filepath.Ext(entry.Name())
+			if ext != "" && ext != ".sh" && ext != ".bash" && ext != ".zsh" {
+				continue
+			}
+			if interpreter := readShebang(filepath.Join(path, dir, entry.Name())); interpreter != "" {
+				counts[interpreter]++
+			}
+		}
+	}
+
+	switch {
+	case len(counts) == 0:
+		return fallback
+	case counts["zsh"] > counts["bash"]:
+		return "zsh"
+	case counts["bash"] > 0:
+		return "bash"
+	default:
+		return "sh"
+	}
+}
+
+// readShebang returns the shell named in a script's shebang line, normalised to bash, zsh or sh
+func readShebang(file string) string {
+	f, err := os.Open(file)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && line == "" {
+		return ""
+	}
+	match := shebangPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return ""
+	}
+	switch match[1] {
+	case "bash", "zsh":
+		return match[1]
+	default:
+		return "sh"
+	}
+}
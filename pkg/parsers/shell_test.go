@@ -0,0 +1,37 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectShellInterpreter(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{"bash scripts", map[string]string{"deploy.sh": "#!/bin/bash\nset -euo pipefail\n", "bin/release": "#!/usr/bin/env bash\n"}, "bash"},
+		{"POSIX sh scripts", map[string]string{"install.sh": "#!/bin/sh\n", "scripts/check.sh": "#!/usr/bin/env sh\n"}, "sh"},
+		{"bash needed by one script", map[string]string{"a.sh": "#!/bin/sh\n", "b.sh": "#!/bin/sh\n", "c.sh": "#!/bin/bash\n"}, "bash"},
+		{"zsh scripts", map[string]string{"setup.zsh": "#!/usr/bin/env zsh\n", "lib.zsh": "#!/bin/zsh\n"}, "zsh"},
+		{"no shebangs", map[string]string{"functions.zsh": "autoload -U compinit\n"}, "zsh"},
+		{"dash counts as sh", map[string]string{"run.sh": "#!/bin/dash\n"}, "sh"},
+		{"non-script files ignored", map[string]string{"run.sh": "#!/bin/sh\n", "tool.py": "#!/usr/bin/env bash\n"}, "sh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			fallback := "bash"
+			if tt.name == "no shebangs" {
+				fallback = "zsh"
+			}
+			if result := RefineLanguage(tmpDir, fallback); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DetectTestFrameworks detects the test frameworks and runners a project uses
+func DetectTestFrameworks(path, language string) []string {
+	switch language {
+	case "python":
+		return detectPythonTestFrameworks(path)
+	case "java", "kotlin", "scala":
+		return detectJVMTestFrameworks(path)
+	case "node", "javascript", "typescript", "bun":
+		return detectNodeTestFrameworks(path)
+	case "go":
+		if hasFileMatching(path, 4, func(name string) bool { return strings.HasSuffix(name, "_test.go") }) {
+			return []string{"go-test"}
+		}
+	case "ruby":
+		return detectRubyTestFrameworks(path)
+	case "php":
+		return detectPHPTestFrameworks(path)
+	case "dotnet", "csharp":
+		return detectDotNetTestFrameworks(path)
+	}
+	return nil
+}
+
+// Python test frameworks
+func detectPythonTestFrameworks(path string) []string {
+	deps := pythonDependencies(path)
+	pyproject := readFile(filepath.Join(path, "pyproject.toml"))
+
+	var frameworks []string
+	_, hasPytest := deps["pytest"]
+	if hasPytest || fileExists(path, "pytest.ini") || fileExists(path, "conftest.py") ||
+		strings.Contains(pyproject, "[tool.pytest") || strings.Contains(readFile(filepath.Join(path, "setup.cfg")), "[tool:pytest]") {
+		frameworks = append(frameworks, "pytest")
+	} else if len(globFiles(path, filepath.Join("tests", "test*.py"))) > 0 || len(globFiles(path, "test*.py")) > 0 {
+		// Test modules without pytest run with the standard library runner
+		frameworks = append(frameworks, "unittest")
+	}
+	if fileExists(path, "tox.ini") || strings.Contains(pyproject, "[tool.tox]") {
+		frameworks = append(frameworks, "tox")
+	}
+	if fileExists(path, "noxfile.py") {
+		frameworks = append(frameworks, "nox")
+	}
+	return frameworks
+}
+
+// JVM test frameworks
+func detectJVMTestFrameworks(path string) []string {
+	var build string
+	for _, file := range []string{"pom.xml", "build.gradle.kts", "build.gradle", "build.sbt", filepath.Join("gradle", "libs.versions.toml")} {
+		build += readFile(filepath.Join(path, file)) + "\n"
+	}
+
+	var frameworks []string
+	// useJUnitPlatform() runs JUnit 5 even when the engine comes from a BOM
+	if regexp.MustCompile(`org\.junit\.jupiter|junit-jupiter|useJUnitPlatform\(\)`).MatchString(build) {
+		frameworks = append(frameworks, "junit5")
+	}
+	if regexp.MustCompile(`<groupId>junit</groupId>|["']junit:junit[:"']|junit-vintage`).MatchString(build) {
+		frameworks = append(frameworks, "junit4")
+	}
+	if strings.Contains(build, "org.testng") {
+		frameworks = append(frameworks, "testng")
+	}
+	if strings.Contains(build, "scalatest") {
+		frameworks = append(frameworks, "scalatest")
+	}
+	if strings.Contains(build, "maven-surefire-plugin") {
+		frameworks = append(frameworks, "surefire")
+	}
+	return frameworks
+}
+
+// Node.js test frameworks
+func detectNodeTestFrameworks(path string) []string {
+	deps := nodeDependencies(path)
+	runners := []struct {
+		name    string
+		pkg     string
+		configs []string
+	}{
+		{"jest", "jest", []string{"jest.config.js", "jest.config.ts", "jest.config.mjs", "jest.config.cjs"}},
+		{"vitest", "vitest", []string{"vitest.config.ts", "vitest.config.js", "vitest.config.mts"}},
+		{"mocha", "mocha", []string{".mocharc.json", ".mocharc.yml", ".mocharc.js", ".mocharc.cjs"}},
+		{"playwright", "@playwright/test", []string{"playwright.config.ts", "playwright.config.js"}},
+	}
+
+	var frameworks []string
+	for _, runner := range runners {
+		_, found := deps[runner.pkg]
+		for _, config := range runner.configs {
+			found = found || fileExists(path, config)
+		}
+		if found {
+			frameworks = append(frameworks, runner.name)
+		}
+	}
+	return frameworks
+}
+
+// Ruby test frameworks
+func detectRubyTestFrameworks(path string) []string {
+	deps := rubyDependencies(path)
+
+	var frameworks []string
+	_, hasRSpec := deps["rspec"]
+	_, hasRSpecRails := deps["rspec-rails"]
+	if hasRSpec || hasRSpecRails || fileExists(path, ".rspec") {
+		frameworks = append(frameworks, "rspec")
+	}
+	// Minitest ships with Ruby and is what Rails generates under test/
+	if _, ok := deps["minitest"]; ok || fileExists(path, filepath.Join("test", "test_helper.rb")) {
+		frameworks = append(frameworks, "minitest")
+	}
+	return frameworks
+}
+
+// PHP test frameworks
+func detectPHPTestFrameworks(path string) []string {
+	deps := phpDependencies(path)
+
+	var frameworks []string
+	if _, ok := deps["pestphp/pest"]; ok || fileExists(path, filepath.Join("tests", "Pest.php")) {
+		frameworks = append(frameworks, "pest")
+	}
+	if _, ok := deps["phpunit/phpunit"]; ok || fileExists(path, "phpunit.xml") || fileExists(path, "phpunit.xml.dist") {
+		frameworks = append(frameworks, "phpunit")
+	}
+	return frameworks
+}
+
+// .NET test frameworks
+func detectDotNetTestFrameworks(path string) []string {
+	var projects string
+	for _, pattern := range []string{"*.csproj", "*/*.csproj", "*/*/*.csproj", "Directory.Packages.props"} {
+		for _, file := range globFiles(path, pattern) {
+			projects += readFile(file) + "\n"
+		}
+	}
+
+	var frameworks []string
+	for _, framework := range []struct {
+		name    string
+		pattern *regexp.Regexp
+	}{
+		{"xunit", regexp.MustCompile(`(?i)Include="xunit(?:\.v3)?"`)},
+		{"nunit", regexp.MustCompile(`(?i)Include="NUnit"`)},
+		{"mstest", regexp.MustCompile(`(?i)Include="MSTest(?:\.TestFramework)?"|Sdk="MSTest\.Sdk`)},
+	} {
+		if framework.pattern.MatchString(projects) {
+			frameworks = append(frameworks, framework.name)
+		}
+	}
+	return frameworks
+}
@@ -0,0 +1,127 @@
+package parsers
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestDetectTestFrameworks(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		files    map[string]string
+		expected []string
+	}{
+		{
+			name:     "pytest and tox",
+			language: "python",
+			files: map[string]string{
+				"pyproject.toml": "[tool.poetry.group.dev.dependencies]\npytest = \"^8.0\"\n",
+				"tox.ini":        "[tox]\nenvlist = py312\n",
+			},
+			expected: []string{"pytest", "tox"},
+		},
+		{
+			name:     "unittest modules",
+			language: "python",
+			files:    map[string]string{"requirements.txt": "requests\n", "tests/test_api.py": "import unittest\n"},
+			expected: []string{"unittest"},
+		},
+		{
+			name:     "nox",
+			language: "python",
+			files:    map[string]string{"conftest.py": "", "noxfile.py": "import nox\n"},
+			expected: []string{"pytest", "nox"},
+		},
+		{
+			name:     "JUnit 5 and Surefire",
+			language: "java",
+			files: map[string]string{
+				"pom.xml": "<project><dependencies><dependency><groupId>org.junit.jupiter</groupId><artifactId>junit-jupiter</artifactId></dependency></dependencies>" +
+					"<build><plugins><plugin><artifactId>maven-surefire-plugin</artifactId></plugin></plugins></build></project>",
+			},
+			expected: []string{"junit5", "surefire"},
+		},
+		{
+			name:     "JUnit 4 with Gradle",
+			language: "kotlin",
+			files:    map[string]string{"build.gradle.kts": "dependencies {\n  testImplementation(\"junit:junit:4.13.2\")\n}\n"},
+			expected: []string{"junit4"},
+		},
+		{
+			name:     "Vitest and Playwright",
+			language: "typescript",
+			files: map[string]string{
+				"package.json":         `{"devDependencies": {"vitest": "^1.4.0"}}`,
+				"playwright.config.ts": "export default {}",
+			},
+			expected: []string{"vitest", "playwright"},
+		},
+		{
+			name:     "Jest config only",
+			language: "node",
+			files:    map[string]string{"package.json": `{}`, "jest.config.js": "module.exports = {}"},
+			expected: []string{"jest"},
+		},
+		{
+			name:     "go test",
+			language: "go",
+			files:    map[string]string{"go.mod": "module x\n", "internal/api/api_test.go": "package api\n"},
+			expected: []string{"go-test"},
+		},
+		{
+			name:     "Go without tests",
+			language: "go",
+			files:    map[string]string{"go.mod": "module x\n", "main.go": "package main\n"},
+			expected: nil,
+		},
+		{
+			name:     "RSpec",
+			language: "ruby",
+			files:    map[string]string{"Gemfile": "gem 'rspec-rails'\n", ".rspec": "--require spec_helper\n"},
+			expected: []string{"rspec"},
+		},
+		{
+			name:     "Rails Minitest",
+			language: "ruby",
+			files:    map[string]string{"Gemfile": "gem 'rails'\n", "test/test_helper.rb": ""},
+			expected: []string{"minitest"},
+		},
+		{
+			name:     "Pest on PHPUnit",
+			language: "php",
+			files:    map[string]string{"composer.json": `{"require-dev": {"pestphp/pest": "^2.0"}}`, "phpunit.xml": "<phpunit/>"},
+			expected: []string{"pest", "phpunit"},
+		},
+		{
+			name:     "xUnit",
+			language: "csharp",
+			files: map[string]string{
+				"tests/Api.Tests/Api.Tests.csproj": `<Project Sdk="Microsoft.NET.Sdk"><ItemGroup><PackageReference Include="xunit" Version="2.7.0" /></ItemGroup></Project>`,
+			},
+			expected: []string{"xunit"},
+		},
+		{
+			name:     "NUnit and MSTest",
+			language: "dotnet",
+			files: map[string]string{
+				"A.Tests/A.Tests.csproj": `<PackageReference Include="NUnit" Version="4.1.0" />`,
+				"B.Tests/B.Tests.csproj": `<PackageReference Include="MSTest.TestFramework" Version="3.2.0" />`,
+			},
+			expected: []string{"nunit", "mstest"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			result := DetectTestFrameworks(tmpDir, tt.language)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
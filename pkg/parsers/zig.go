@@ -0,0 +1,24 @@
+package parsers
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Zig detection
+func detectZigVersion(path string) string {
+	if content := readFile(filepath.Join(path, ".zigversion")); content != "" {
+		return strings.TrimSpace(content)
+	}
+	if version := toolVersionsEntry(path, "zig"); version != "" {
+		return version
+	}
+	if content := readFile(filepath.Join(path, "build.zig.zon")); content != "" {
+		re := regexp.MustCompile(`\.minimum_zig_version\s*=\s*"([^"]+)"`)
+		if match := re.FindStringSubmatch(content); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
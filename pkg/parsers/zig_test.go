@@ -0,0 +1,29 @@
+package parsers
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDetectZigVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{"build.zig.zon minimum", map[string]string{"build.zig.zon": ".{\n    .name = \"app\",\n    .minimum_zig_version = \"0.12.0\",\n}"}, "0.12.0"},
+		{".zigversion pin", map[string]string{"build.zig": "", ".zigversion": "0.13.0\n"}, "0.13.0"},
+		{"build.zig only", map[string]string{"build.zig": "const std = @import(\"std\");"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTestFiles(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			if result := DetectVersion(tmpDir, "zig", "zig"); result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Compare compares dotted numeric versions component by component, returning a
+// negative number, zero or a positive number. Missing components count as 0,
+// so 8 equals 8.0.
+func Compare(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for k := 0; k < len(as) || k < len(bs); k++ {
+		var x, y int
+		if k < len(as) {
+			x, _ = strconv.Atoi(as[k])
+		}
+		if k < len(bs) {
+			y, _ = strconv.Atoi(bs[k])
+		}
+		if x != y {
+			return x - y
+		}
+	}
+	return 0
+}
+
+// comparePrefix compares a version with want on the components want names, so
+// 3.12.4 equals 3.12
+func comparePrefix(version, want string) int {
+	have, wants := strings.Split(version, "."), strings.Split(want, ".")
+	for k := range wants {
+		var x int
+		if k < len(have) {
+			x, _ = strconv.Atoi(have[k])
+		}
+		y, _ := strconv.Atoi(wants[k])
+		if x != y {
+			return x - y
+		}
+	}
+	return 0
+}
+
+// Comparison is a single version requirement such as >=17
+type Comparison struct {
+	Op      string
+	Version string
+}
+
+// Constraint is a set of comparisons a version must all satisfy, e.g. >=3.11, <4
+type Constraint []Comparison
+
+var comparisonPattern = regexp.MustCompile(`^(>=|<=|!=|>|<|=)?\s*v?(\d+(?:\.\d+)*)$`)
+
+// ParseConstraint parses comma-separated comparisons such as ">=3.11, <4". A bare
+// version is an equality comparison.
+func ParseConstraint(constraint string) (Constraint, error) {
+	var c Constraint
+	for _, part := range strings.Split(constraint, ",") {
+		match := comparisonPattern.FindStringSubmatch(strings.TrimSpace(part))
+		if match == nil {
+			return nil, fmt.Errorf("invalid version constraint %q", constraint)
+		}
+		op := match[1]
+		if op == "" {
+			op = "="
+		}
+		c = append(c, Comparison{Op: op, Version: match[2]})
+	}
+	return c, nil
+}
+
+// Matches checks a version against every comparison. Only as many components as
+// a comparison names are compared, so 3.12.4 satisfies =3.12.
+func (c Constraint) Matches(version string) bool {
+	for _, comparison := range c {
+		if !comparison.Matches(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches checks a version against the comparison
+func (c Comparison) Matches(version string) bool {
+	cmp := comparePrefix(version, c.Version)
+	switch c.Op {
+	case ">=":
+		return cmp >= 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	case "<":
+		return cmp < 0
+	case "!=":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+// rangeToken matches one comparison of a version range, e.g. >=3.11, ^18.2.0,
+// ~=3.11 or 3.12.*
+var rangeToken = regexp.MustCompile(`^(>=|<=|!=|==|~=|~>|>|<|=|\^|~)?v?(\d+(?:\.\d+)*)(?:\.[*xX])*$`)
+
+// rangeOperatorSpace matches the space some ranges put between operator and version
+var rangeOperatorSpace = regexp.MustCompile(`(>=|<=|!=|==|~=|~>|>|<|=|\^|~)\s+`)
+
+// ParseRange parses a version range as written in package manifests into
+// alternative constraints, any of which a version may satisfy. It reads npm and
+// Poetry ranges (^18.2, ~3.11, >=18 <21, 18.x, 18 || 20), PEP 440 specifiers
+// (>=3.11,<3.13, ~=3.11, ==3.12.*), Composer alternatives (^7.4|^8.0) and Ruby's
+// pessimistic ~> operator.
+func ParseRange(spec string) ([]Constraint, error) {
+	var alternatives []Constraint
+	for _, alternative := range strings.Split(strings.ReplaceAll(spec, "||", "|"), "|") {
+		alternative = rangeOperatorSpace.ReplaceAllString(strings.ReplaceAll(alternative, ",", " "), "$1")
+		c := Constraint{}
+		for _, token := range strings.Fields(alternative) {
+			if token == "*" || token == "x" {
+				continue
+			}
+			match := rangeToken.FindStringSubmatch(token)
+			if match == nil {
+				return nil, fmt.Errorf("invalid version range %q", spec)
+			}
+			op, v := match[1], match[2]
+			parts := strings.Split(v, ".")
+			switch op {
+			case "", "=", "==":
+				c = append(c, Comparison{Op: "=", Version: v})
+			case "^":
+				// Up to the next release of the first non-zero component
+				i := 0
+				for i < len(parts)-1 && parts[i] == "0" {
+					i++
+				}
+				c = append(c, Comparison{Op: ">=", Version: v}, Comparison{Op: "<", Version: bump(parts[:i+1])})
+			case "~":
+				// Up to the next minor release, or the next major when only the major is given
+				c = append(c, Comparison{Op: ">=", Version: v}, Comparison{Op: "<", Version: bump(parts[:min(len(parts), 2)])})
+			case "~=", "~>":
+				// Up to the next release of the second-to-last component
+				if len(parts) < 2 {
+					return nil, fmt.Errorf("invalid version range %q", spec)
+				}
+				c = append(c, Comparison{Op: ">=", Version: v}, Comparison{Op: "<", Version: bump(parts[:len(parts)-1])})
+			default:
+				c = append(c, Comparison{Op: op, Version: v})
+			}
+		}
+		alternatives = append(alternatives, c)
+	}
+	return alternatives, nil
+}
+
+// bump increments the last component of a version, e.g. 3.11 to 3.12
+func bump(parts []string) string {
+	last, _ := strconv.Atoi(parts[len(parts)-1])
+	return strings.Join(append(parts[:len(parts)-1:len(parts)-1], strconv.Itoa(last+1)), ".")
+}
@@ -0,0 +1,107 @@
+package version
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int // Sign of the result
+	}{
+		{"3.12", "3.9", 1},
+		{"1.22.5", "1.22", 1},
+		{"8", "8.0", 0},
+		{"20", "22", -1},
+		{"10.0", "9.0", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			result := Compare(tt.a, tt.b)
+			if sign := (result > 0) == (tt.expected > 0) && (result < 0) == (tt.expected < 0); !sign {
+				t.Errorf("Compare(%q, %q) = %d, expected sign of %d", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{">=17", "21", true},
+		{">=17", "11", false},
+		{">=3.11, <4", "3.12.4", true},
+		{">=3.11, <4", "4.0", false},
+		{"3.12", "3.12.4", true},
+		{"=3.12", "3.11", false},
+		{"!=20", "22", true},
+		{"<=1.22", "1.22.9", true},
+		{">1.22", "1.22.9", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result := c.Matches(tt.version); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "lts", "~> 3.1", ">=3.11,"} {
+		if _, err := ParseConstraint(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		spec     string
+		version  string
+		expected bool
+	}{
+		{">=18", "22", true},
+		{">=18", "16", false},
+		{"^3.11", "3.12", true},
+		{"^3.11", "4.0", false},
+		{"^0.2.3", "0.3", false},
+		{"~3.11", "3.11.4", true},
+		{"~3.11", "3.12", false},
+		{"~=3.11", "3.13", true},
+		{"~> 3.2.1", "3.3", false},
+		{">=3.11,<3.13", "3.13", false},
+		{">= 18 < 21", "20.11.1", true},
+		{"18.x || 20.x", "20", true},
+		{"^7.4|^8.0", "8.3", true},
+		{"==3.12.*", "3.12.4", true},
+		{"*", "22", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+" "+tt.version, func(t *testing.T) {
+			ranges, err := ParseRange(tt.spec)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			result := false
+			for _, c := range ranges {
+				result = result || c.Matches(tt.version)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"lts/*", "~=3", "latest"} {
+		if _, err := ParseRange(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}